@@ -17,28 +17,250 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// defaultProfileName is the profile selected when neither
+// KANBANIZE_DEFAULT_PROFILE nor a caller-supplied profile argument says
+// otherwise.
+const defaultProfileName = "default"
+
+// ProfileConfig holds everything the Kanbanize client needs to talk to one
+// workspace/tenant. A deployment that only ever talks to one workspace never
+// has to name a profile: the unsuffixed KANBANIZE_API_KEY/KANBANIZE_BASE_URL
+// (and friends) populate the "default" profile's ProfileConfig implicitly.
+type ProfileConfig struct {
+	APIKey  string
+	BaseURL string
+
+	// RateLimitRPS and RateLimitBurst configure this profile's client-side
+	// rate limiter (see kanbanize.NewTokenBucketRateLimiter /
+	// Client.SetRateLimiter), so one over-quota or slow tenant can't starve
+	// the retry/rate budget of another sharing the same process. Zero means
+	// no proactive limiting for this profile.
+	RateLimitRPS   float64
+	RateLimitBurst int
+
+	// Timeout overrides the Kanbanize client's per-request HTTP timeout for
+	// this profile (see kanbanize.WithTimeout). Zero means the client's
+	// default.
+	Timeout time.Duration
+}
+
+// Config holds the resolved configuration for the Kanbanize client and MCP
+// server. KanbanizeAPIKey/KanbanizeBaseURL mirror Profiles[DefaultProfile]
+// for callers that only care about a single workspace; multi-tenant callers
+// should resolve a profile via Profile instead.
 type Config struct {
-	KanbanizeAPIKey string
+	KanbanizeAPIKey  string
 	KanbanizeBaseURL string
+
+	// Profiles holds every named workspace profile, including an entry for
+	// DefaultProfile. Look one up with Profile rather than indexing this
+	// directly, so an empty/omitted profile name falls back correctly.
+	Profiles map[string]ProfileConfig
+
+	// DefaultProfile names the Profiles entry used when a caller omits a
+	// profile. Set via KANBANIZE_DEFAULT_PROFILE; "default" otherwise.
+	DefaultProfile string
 }
 
-func Load() (*Config, error) {
-	apiKey := os.Getenv("KANBANIZE_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("KANBANIZE_API_KEY environment variable is required")
+// Profile resolves name to a ProfileConfig, falling back to DefaultProfile
+// when name is empty so every MCP tool can thread an optional profile
+// argument straight through without its own fallback logic.
+func (cfg *Config) Profile(name string) (ProfileConfig, error) {
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		return ProfileConfig{}, fmt.Errorf("config: unknown profile %q", name)
+	}
+	return profile, nil
+}
+
+// fromValues builds a Config from a merged provider value map: it groups
+// every KANBANIZE_*__<profile> key by profile name, then seeds the default
+// profile's APIKey/BaseURL/etc. from the unsuffixed keys wherever a
+// profile-suffixed value wasn't also given for it (profile-suffixed always
+// wins, same "more specific overrides less specific" rule Loader uses
+// between providers). Every profile is then validated — required fields,
+// API key charset/length, base URL scheme/host — with every problem found
+// collected onto a single *ConfigError rather than returning on the first
+// one, so a misconfigured deployment can be fixed in one pass instead of
+// "run, read the first error, set one var, run again".
+func fromValues(values map[string]string) (*Config, error) {
+	defaultProfile := values["KANBANIZE_DEFAULT_PROFILE"]
+	if defaultProfile == "" {
+		defaultProfile = defaultProfileName
+	}
+
+	errs := &ConfigError{}
+
+	profiles := make(map[string]ProfileConfig)
+	for key, value := range values {
+		prefix, profile, ok := strings.Cut(key, profileKeySeparator)
+		if !ok || value == "" {
+			continue
+		}
+		pc := profiles[profile]
+		setProfileField(errs, &pc, prefix, value, profile)
+		profiles[profile] = pc
+	}
+
+	base := profiles[defaultProfile]
+	if base.APIKey == "" {
+		base.APIKey = values["KANBANIZE_API_KEY"]
+	}
+	if base.BaseURL == "" {
+		base.BaseURL = values["KANBANIZE_BASE_URL"]
+	}
+	profiles[defaultProfile] = base
+
+	for name, pc := range profiles {
+		validateProfile(errs, name, &pc)
+		profiles[name] = pc
 	}
 
-	baseURL := os.Getenv("KANBANIZE_BASE_URL")
-	if baseURL == "" {
-		return nil, fmt.Errorf("KANBANIZE_BASE_URL environment variable is required")
+	if err := errs.errOrNil(); err != nil {
+		return nil, err
 	}
 
+	dp := profiles[defaultProfile]
 	return &Config{
-		KanbanizeAPIKey:  apiKey,
-		KanbanizeBaseURL: baseURL,
+		KanbanizeAPIKey:  dp.APIKey,
+		KanbanizeBaseURL: dp.BaseURL,
+		Profiles:         profiles,
+		DefaultProfile:   defaultProfile,
 	}, nil
-}
\ No newline at end of file
+}
+
+// setProfileField applies one KANBANIZE_<field>__<profile> value onto pc,
+// recording a FieldError onto errs if field isn't a recognized
+// profileFieldKeys prefix or value doesn't parse for that field's type,
+// rather than aborting the rest of the merge.
+func setProfileField(errs *ConfigError, pc *ProfileConfig, field, value, profile string) {
+	path := "/profiles/" + profile
+	switch field {
+	case "KANBANIZE_API_KEY":
+		pc.APIKey = value
+	case "KANBANIZE_BASE_URL":
+		pc.BaseURL = value
+	case "KANBANIZE_RATE_LIMIT_RPS":
+		rps, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs.add(path+"/rate_limit_rps", "KANBANIZE_RATE_LIMIT_RPS: %s", err)
+			return
+		}
+		pc.RateLimitRPS = rps
+	case "KANBANIZE_RATE_LIMIT_BURST":
+		burst, err := strconv.Atoi(value)
+		if err != nil {
+			errs.add(path+"/rate_limit_burst", "KANBANIZE_RATE_LIMIT_BURST: %s", err)
+			return
+		}
+		pc.RateLimitBurst = burst
+	case "KANBANIZE_TIMEOUT_MS":
+		ms, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			errs.add(path+"/timeout_ms", "KANBANIZE_TIMEOUT_MS: %s", err)
+			return
+		}
+		pc.Timeout = time.Duration(ms) * time.Millisecond
+	default:
+		// Not a recognized profile field key; isProfileFieldKey already
+		// filtered these out upstream, so this would only happen if the two
+		// lists drift apart.
+		errs.add(path, "unrecognized field %q", field)
+	}
+}
+
+// values renders cfg back into the flat map shape fromValues expects, so a
+// Loader can diff one snapshot against the next without a field-by-field
+// struct comparison.
+func (cfg *Config) values() map[string]string {
+	values := map[string]string{
+		"KANBANIZE_API_KEY":         cfg.KanbanizeAPIKey,
+		"KANBANIZE_BASE_URL":        cfg.KanbanizeBaseURL,
+		"KANBANIZE_DEFAULT_PROFILE": cfg.DefaultProfile,
+	}
+	for name, pc := range cfg.Profiles {
+		values["KANBANIZE_API_KEY"+profileKeySeparator+name] = pc.APIKey
+		values["KANBANIZE_BASE_URL"+profileKeySeparator+name] = pc.BaseURL
+		if pc.RateLimitRPS != 0 {
+			values["KANBANIZE_RATE_LIMIT_RPS"+profileKeySeparator+name] = strconv.FormatFloat(pc.RateLimitRPS, 'f', -1, 64)
+		}
+		if pc.RateLimitBurst != 0 {
+			values["KANBANIZE_RATE_LIMIT_BURST"+profileKeySeparator+name] = strconv.Itoa(pc.RateLimitBurst)
+		}
+		if pc.Timeout != 0 {
+			values["KANBANIZE_TIMEOUT_MS"+profileKeySeparator+name] = strconv.FormatInt(pc.Timeout.Milliseconds(), 10)
+		}
+	}
+	return values
+}
+
+// Load resolves Config from the default provider chain: environment
+// variables, then a config file named by BUSINESSMAP_CONFIG if set (see
+// FileProvider), precedence env < file. It does not consult a remote KV
+// backend; use NewLoader directly (e.g. with a ConsulProvider appended) for
+// that, since a remote lookup needs a context and may block.
+//
+// Any value of the form "<scheme>:<ref>" (e.g. "vault:secret/data/kanbanize
+// #api_key") is resolved through DefaultSecretResolvers before validation,
+// so an operator can reference Vault, AWS Secrets Manager, or a mounted
+// secret file instead of baking a long-lived API key into the process
+// environment.
+func Load() (*Config, error) {
+	providers := []Provider{EnvProvider{}}
+	if path := os.Getenv("BUSINESSMAP_CONFIG"); path != "" {
+		providers = append(providers, FileProvider{Path: path})
+	}
+	loader := NewLoader(providers...)
+	loader.SetSecretResolvers(DefaultSecretResolvers())
+	return loader.Load(context.Background())
+}
+
+// PrintSchema writes an example BUSINESSMAP_CONFIG file to w, in the same
+// `key: value` shape FileProvider parses, documenting every recognized key
+// (including the profile-suffixed and secret-reference forms) so a new
+// deployment can start from a working file instead of discovering each
+// required key one Load failure at a time.
+func PrintSchema(w io.Writer) error {
+	_, err := io.WriteString(w, schemaExample)
+	return err
+}
+
+const schemaExample = `# businessmap-mcp configuration file (see BUSINESSMAP_CONFIG).
+# Every key here may also be set as an environment variable of the same name.
+
+# Required: the default profile's API key and base URL.
+KANBANIZE_API_KEY: your-api-key-here
+KANBANIZE_BASE_URL: https://yourcompany.kanbanize.com
+
+# Optional: which profile an omitted "profile" tool argument resolves to.
+# Defaults to "default".
+# KANBANIZE_DEFAULT_PROFILE: default
+
+# Optional: additional named profiles, e.g. for a second workspace. Suffix
+# any of the keys above (and the rate-limit/timeout settings below) with
+# "__<profile>".
+# KANBANIZE_API_KEY__staging: your-staging-api-key
+# KANBANIZE_BASE_URL__staging: https://yourcompany-staging.kanbanize.com
+# KANBANIZE_RATE_LIMIT_RPS__staging: 2.5
+# KANBANIZE_RATE_LIMIT_BURST__staging: 5
+# KANBANIZE_TIMEOUT_MS__staging: 15000
+
+# Optional: reference a value instead of inlining it, e.g. to read an API
+# key from Vault, AWS Secrets Manager, or a Docker/Kubernetes secret mount
+# instead of baking it into this file (see SecretResolver):
+# KANBANIZE_API_KEY: vault:secret/data/kanbanize#api_key
+# KANBANIZE_API_KEY: aws-sm://kanbanize/api-key#api_key
+# KANBANIZE_API_KEY: file:/run/secrets/kanbanize_api_key
+`
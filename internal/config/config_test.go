@@ -0,0 +1,126 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromValues_SingleProfileUsesUnsuffixedKeys(t *testing.T) {
+	cfg, err := fromValues(map[string]string{
+		"KANBANIZE_API_KEY":  "top-level-key",
+		"KANBANIZE_BASE_URL": "https://example.com",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.KanbanizeAPIKey != "top-level-key" || cfg.KanbanizeBaseURL != "https://example.com" {
+		t.Errorf("unexpected top-level fields: %+v", cfg)
+	}
+	if cfg.DefaultProfile != "default" {
+		t.Errorf("expected DefaultProfile=\"default\", got %q", cfg.DefaultProfile)
+	}
+	dp, err := cfg.Profile("")
+	if err != nil {
+		t.Fatalf("expected the default profile to resolve, got %v", err)
+	}
+	if dp.APIKey != "top-level-key" {
+		t.Errorf("expected default profile to inherit the unsuffixed key, got %q", dp.APIKey)
+	}
+}
+
+func TestFromValues_NamedProfilesAndRateLimitSettings(t *testing.T) {
+	cfg, err := fromValues(map[string]string{
+		"KANBANIZE_API_KEY":                   "default-key",
+		"KANBANIZE_BASE_URL":                  "https://default.example.com",
+		"KANBANIZE_API_KEY__staging":          "staging-key",
+		"KANBANIZE_BASE_URL__staging":         "https://staging.example.com",
+		"KANBANIZE_RATE_LIMIT_RPS__staging":   "2.5",
+		"KANBANIZE_RATE_LIMIT_BURST__staging": "5",
+		"KANBANIZE_TIMEOUT_MS__staging":       "15000",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	staging, err := cfg.Profile("staging")
+	if err != nil {
+		t.Fatalf("expected the staging profile to resolve, got %v", err)
+	}
+	if staging.APIKey != "staging-key" || staging.BaseURL != "https://staging.example.com" {
+		t.Errorf("unexpected staging profile: %+v", staging)
+	}
+	if staging.RateLimitRPS != 2.5 || staging.RateLimitBurst != 5 {
+		t.Errorf("unexpected staging rate limit settings: %+v", staging)
+	}
+	if staging.Timeout != 15*time.Second {
+		t.Errorf("expected staging timeout of 15s, got %v", staging.Timeout)
+	}
+
+	dp, err := cfg.Profile("")
+	if err != nil {
+		t.Fatalf("expected the default profile to resolve, got %v", err)
+	}
+	if dp.APIKey != "default-key" {
+		t.Errorf("expected omitting a profile to fall back to default, got %q", dp.APIKey)
+	}
+}
+
+func TestFromValues_SuffixedKeyOverridesUnsuffixedForDefaultProfile(t *testing.T) {
+	cfg, err := fromValues(map[string]string{
+		"KANBANIZE_API_KEY":          "unsuffixed-key",
+		"KANBANIZE_BASE_URL":         "https://unsuffixed.example.com",
+		"KANBANIZE_API_KEY__default": "explicit-default-key",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.KanbanizeAPIKey != "explicit-default-key" {
+		t.Errorf("expected the explicitly-suffixed default profile key to win, got %q", cfg.KanbanizeAPIKey)
+	}
+}
+
+func TestFromValues_CustomDefaultProfileSelector(t *testing.T) {
+	cfg, err := fromValues(map[string]string{
+		"KANBANIZE_DEFAULT_PROFILE": "prod",
+		"KANBANIZE_API_KEY__prod":   "prod-key",
+		"KANBANIZE_BASE_URL__prod":  "https://prod.example.com",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.DefaultProfile != "prod" {
+		t.Errorf("expected DefaultProfile=prod, got %q", cfg.DefaultProfile)
+	}
+	if cfg.KanbanizeAPIKey != "prod-key" {
+		t.Errorf("expected the prod profile to seed the top-level fields, got %q", cfg.KanbanizeAPIKey)
+	}
+
+	if _, err := cfg.Profile("nonexistent"); err == nil {
+		t.Fatal("expected an error resolving a profile that was never configured")
+	}
+}
+
+func TestFromValues_MissingDefaultProfileErrors(t *testing.T) {
+	if _, err := fromValues(map[string]string{
+		"KANBANIZE_API_KEY__staging":  "staging-key",
+		"KANBANIZE_BASE_URL__staging": "https://staging.example.com",
+	}); err == nil {
+		t.Fatal("expected an error when the default profile has no API key or base URL")
+	}
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConsulProvider is a remote Provider/Watcher backed by Consul's KV HTTP API
+// (https://developer.hashicorp.com/consul/api-docs/kv), read directly over
+// net/http rather than a full Consul client SDK, since all this needs is
+// "GET a prefix, optionally block on an index changing." Keys under
+// KeyPrefix are expected to be named after Config's known keys, e.g.
+// "<KeyPrefix>/KANBANIZE_API_KEY". Other remote-KV backends (etcd, ServiceComb
+// Kie) can implement the same Provider/Watcher pair without Loader caring
+// which one is in use.
+type ConsulProvider struct {
+	// Address is Consul's HTTP API base, e.g. "http://127.0.0.1:8500".
+	Address string
+	// KeyPrefix is the KV path holding this service's config, e.g.
+	// "businessmap-mcp/config".
+	KeyPrefix string
+	// WaitTime bounds each blocking query (Consul's own default is 5m);
+	// zero defaults to 2 minutes.
+	WaitTime time.Duration
+
+	// HTTPClient is used for every request; a zero value uses
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func (p ConsulProvider) Name() string {
+	return fmt.Sprintf("consul(%s)", p.KeyPrefix)
+}
+
+func (p ConsulProvider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (p ConsulProvider) waitTime() time.Duration {
+	if p.WaitTime > 0 {
+		return p.WaitTime
+	}
+	return 2 * time.Minute
+}
+
+// consulKVEntry mirrors one element of Consul's
+// GET /v1/kv/<prefix>?recurse response.
+type consulKVEntry struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"` // base64-encoded
+}
+
+// fetch issues one GET against Consul's KV API, recursing under KeyPrefix.
+// index and wait, when wait > 0, turn this into a blocking query that
+// doesn't return until Consul's index for this prefix advances past index or
+// wait elapses, whichever comes first (Consul's long-polling pattern).
+func (p ConsulProvider) fetch(ctx context.Context, index uint64, wait time.Duration) (entries []consulKVEntry, newIndex uint64, err error) {
+	url := fmt.Sprintf("%s/v1/kv/%s?recurse", strings.TrimRight(p.Address, "/"), strings.TrimLeft(p.KeyPrefix, "/"))
+	if wait > 0 {
+		url += fmt.Sprintf("&index=%d&wait=%s", index, wait)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("consul: building request: %w", err)
+	}
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("consul: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		// No keys under this prefix yet; not an error, just nothing to add.
+		return nil, parseConsulIndex(resp.Header.Get("X-Consul-Index")), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("consul: GET %s returned status %d", url, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, 0, fmt.Errorf("consul: decoding response: %w", err)
+	}
+
+	return entries, parseConsulIndex(resp.Header.Get("X-Consul-Index")), nil
+}
+
+func parseConsulIndex(header string) uint64 {
+	index, _ := strconv.ParseUint(header, 10, 64)
+	return index
+}
+
+// toValues decodes entries' base64 values and maps each key, relative to
+// KeyPrefix, onto Config's flat value names.
+func (p ConsulProvider) toValues(entries []consulKVEntry) map[string]string {
+	values := make(map[string]string, len(entries))
+	prefix := strings.Trim(p.KeyPrefix, "/") + "/"
+	for _, entry := range entries {
+		name := strings.TrimPrefix(entry.Key, prefix)
+		decoded, err := base64.StdEncoding.DecodeString(entry.Value)
+		if err != nil {
+			continue
+		}
+		values[name] = string(decoded)
+	}
+	return values
+}
+
+// Load fetches the current values under KeyPrefix without blocking.
+func (p ConsulProvider) Load(ctx context.Context) (map[string]string, error) {
+	entries, _, err := p.fetch(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return p.toValues(entries), nil
+}
+
+// Watch long-polls Consul's blocking query API for changes under KeyPrefix,
+// sending a freshly-decoded value map on changes each time Consul's index
+// for this prefix advances. A request failure (Consul unreachable, timeout)
+// is retried after an exponential backoff capped at 30s; it never sends on
+// changes or returns from Watch, so Loader.Watch keeps serving its last-good
+// Config through an outage instead of crashing or going stale silently.
+func (p ConsulProvider) Watch(ctx context.Context, changes chan<- map[string]string) {
+	var index uint64
+	backoff := time.Second
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		entries, newIndex, err := p.fetch(ctx, index, p.waitTime())
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > 30*time.Second {
+				backoff = 30 * time.Second
+			}
+			continue
+		}
+		backoff = time.Second
+
+		// A blocking query that times out without a change returns the same
+		// index; only report a change when it actually advanced.
+		if newIndex == index {
+			continue
+		}
+		index = newIndex
+
+		select {
+		case changes <- p.toValues(entries):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
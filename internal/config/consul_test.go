@@ -0,0 +1,165 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// consulKVFixture serves a single key under "businessmap-mcp/config" whose
+// value and Consul index can be swapped out mid-test, and holds a blocking
+// query until its index advances past the caller's index, or for at most
+// 200ms (mirroring real Consul's long-poll behavior), rather than returning
+// immediately.
+type consulKVFixture struct {
+	index int64
+	value atomic.Value // string
+}
+
+func newConsulKVFixture(initial string) *consulKVFixture {
+	f := &consulKVFixture{index: 1}
+	f.value.Store(initial)
+	return f
+}
+
+func (f *consulKVFixture) set(value string) {
+	f.value.Store(value)
+	atomic.AddInt64(&f.index, 1)
+}
+
+func (f *consulKVFixture) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		currentIndex := atomic.LoadInt64(&f.index)
+
+		if waitIndex := r.URL.Query().Get("index"); waitIndex != "" {
+			requested, _ := strconv.ParseInt(waitIndex, 10, 64)
+			deadline := time.Now().Add(200 * time.Millisecond)
+			for requested >= currentIndex && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+				currentIndex = atomic.LoadInt64(&f.index)
+			}
+		}
+
+		w.Header().Set("X-Consul-Index", strconv.FormatInt(currentIndex, 10))
+		value := f.value.Load().(string)
+		fmt.Fprintf(w, `[{"Key":"businessmap-mcp/config/KANBANIZE_API_KEY","Value":"%s"}]`,
+			base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+}
+
+func TestConsulProvider_Load(t *testing.T) {
+	fixture := newConsulKVFixture("consul-key")
+	server := httptest.NewServer(fixture.handler())
+	defer server.Close()
+
+	p := ConsulProvider{Address: server.URL, KeyPrefix: "businessmap-mcp/config"}
+	values, err := p.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if values["KANBANIZE_API_KEY"] != "consul-key" {
+		t.Errorf("expected KANBANIZE_API_KEY=consul-key, got %q", values["KANBANIZE_API_KEY"])
+	}
+}
+
+func TestConsulProvider_Load_MissingPrefixIsNotAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Consul-Index", "1")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	p := ConsulProvider{Address: server.URL, KeyPrefix: "businessmap-mcp/config"}
+	values, err := p.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error for an absent prefix, got %v", err)
+	}
+	if len(values) != 0 {
+		t.Errorf("expected no values for an absent prefix, got %v", values)
+	}
+}
+
+func TestConsulProvider_Watch_SendsOnIndexAdvance(t *testing.T) {
+	fixture := newConsulKVFixture("initial-key")
+	server := httptest.NewServer(fixture.handler())
+	defer server.Close()
+
+	p := ConsulProvider{Address: server.URL, KeyPrefix: "businessmap-mcp/config", WaitTime: 2 * time.Second}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes := make(chan map[string]string, 1)
+	go p.Watch(ctx, changes)
+
+	// The first blocking query starts from index 0, so Consul's own index
+	// (1) already counts as "advanced" and should be reported once before
+	// Watch settles into waiting on the fixture's real index.
+	select {
+	case values := <-changes:
+		if values["KANBANIZE_API_KEY"] != "initial-key" {
+			t.Fatalf("expected initial-key on first report, got %q", values["KANBANIZE_API_KEY"])
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the initial snapshot")
+	}
+
+	fixture.set("rotated-key")
+
+	select {
+	case values := <-changes:
+		if values["KANBANIZE_API_KEY"] != "rotated-key" {
+			t.Errorf("expected rotated-key after the index advanced, got %q", values["KANBANIZE_API_KEY"])
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the rotated snapshot")
+	}
+}
+
+func TestConsulProvider_Watch_StopsOnContextCancel(t *testing.T) {
+	fixture := newConsulKVFixture("some-key")
+	server := httptest.NewServer(fixture.handler())
+	defer server.Close()
+
+	p := ConsulProvider{Address: server.URL, KeyPrefix: "businessmap-mcp/config", WaitTime: 2 * time.Second}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan map[string]string, 1)
+
+	done := make(chan struct{})
+	go func() {
+		p.Watch(ctx, changes)
+		close(done)
+	}()
+
+	<-changes // drain the initial report so Watch moves on to its next blocking call
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Watch to return promptly after ctx was canceled")
+	}
+}
@@ -0,0 +1,468 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// scalarKeys lists the config keys that apply globally rather than to one
+// profile: the unsuffixed KANBANIZE_API_KEY/KANBANIZE_BASE_URL (which seed
+// the default profile) and the selector naming which profile "default"
+// refers to.
+var scalarKeys = []string{"KANBANIZE_API_KEY", "KANBANIZE_BASE_URL", "KANBANIZE_DEFAULT_PROFILE"}
+
+// profileFieldKeys lists the per-profile key prefixes a Provider may supply,
+// each suffixed "__<profile>" (e.g. "KANBANIZE_API_KEY__staging"). See
+// fromValues and EnvProvider.Load.
+var profileFieldKeys = []string{
+	"KANBANIZE_API_KEY",
+	"KANBANIZE_BASE_URL",
+	"KANBANIZE_RATE_LIMIT_RPS",
+	"KANBANIZE_RATE_LIMIT_BURST",
+	"KANBANIZE_TIMEOUT_MS",
+}
+
+// profileKeySeparator joins a profileFieldKeys prefix to the profile name it
+// applies to, e.g. "KANBANIZE_API_KEY__staging".
+const profileKeySeparator = "__"
+
+// isProfileFieldKey reports whether key is one of profileFieldKeys suffixed
+// with a profile name.
+func isProfileFieldKey(key string) bool {
+	prefix, _, ok := strings.Cut(key, profileKeySeparator)
+	if !ok {
+		return false
+	}
+	for _, k := range profileFieldKeys {
+		if k == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// isRelevantKey reports whether key is something Load should ever return:
+// either a recognized scalar key or a profile-suffixed field key. It's
+// shared by EnvProvider (to decide which environment variables to surface
+// out of the whole process environment) and FileProvider/ConsulProvider
+// implicitly, via mergeValues discarding anything else.
+func isRelevantKey(key string) bool {
+	for _, k := range scalarKeys {
+		if k == key {
+			return true
+		}
+	}
+	return isProfileFieldKey(key)
+}
+
+// Provider supplies configuration values as a flat string map, keyed by the
+// same names as Config's environment variables (KANBANIZE_API_KEY,
+// KANBANIZE_BASE_URL). A provider with nothing to contribute returns an
+// empty map and a nil error, not an error — a missing file or unset env var
+// is normal, not exceptional.
+type Provider interface {
+	// Name identifies the provider for error messages and logging.
+	Name() string
+	// Load returns this provider's current values.
+	Load(ctx context.Context) (map[string]string, error)
+}
+
+// Watcher is implemented by providers that can notify a Loader of changes
+// without being polled on a timer, e.g. ConsulProvider's blocking queries.
+// Loader.Watch starts one goroutine per Watcher in the chain.
+type Watcher interface {
+	Provider
+	// Watch blocks until ctx is done, sending a freshly-loaded value map to
+	// changes each time this provider's values change. It's the provider's
+	// job to retry transient failures internally; Watch should only return
+	// when ctx is done.
+	Watch(ctx context.Context, changes chan<- map[string]string)
+}
+
+// EnvProvider reads configuration straight from the process environment,
+// including the KANBANIZE_API_KEY__<profile> / KANBANIZE_BASE_URL__<profile>
+// naming convention (see fromValues) for any number of profiles — there's no
+// fixed list of profile names to check, so it scans os.Environ() rather than
+// just os.Getenv for each known key. It's always first in Load's default
+// chain and has the lowest precedence: anything set by a file or a remote
+// backend overrides it.
+type EnvProvider struct{}
+
+func (EnvProvider) Name() string { return "env" }
+
+func (EnvProvider) Load(ctx context.Context) (map[string]string, error) {
+	values := make(map[string]string, len(scalarKeys))
+	for _, entry := range os.Environ() {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || value == "" || !isRelevantKey(key) {
+			continue
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// FileProvider reads configuration from a flat `key: value` file (one
+// assignment per line, '#' comments, blank lines ignored) — the subset of
+// YAML needed for a flat key/value config, so BUSINESSMAP_CONFIG doesn't
+// require vendoring a full YAML or TOML decoder. Per-profile settings use the
+// same KANBANIZE_API_KEY__<profile> keys as the environment (e.g.
+// `KANBANIZE_API_KEY__staging: ...`), so a single naming convention covers
+// both providers. Keys Load doesn't recognize are still returned, and
+// Loader.Load reports them as configuration errors. A missing file is not an
+// error (Load falls back to env).
+type FileProvider struct {
+	Path string
+}
+
+func (p FileProvider) Name() string { return fmt.Sprintf("file(%s)", p.Path) }
+
+func (p FileProvider) Load(ctx context.Context) (map[string]string, error) {
+	values := make(map[string]string)
+
+	f, err := os.Open(p.Path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", p.Path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			key, value, ok = strings.Cut(line, "=")
+		}
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", p.Path, err)
+	}
+
+	return values, nil
+}
+
+// Loader merges a chain of Providers into a Config, later providers taking
+// precedence over earlier ones. The recommended chain order is defaults
+// (implicit), env, file, remote — i.e. NewLoader(EnvProvider{},
+// FileProvider{...}, consulProvider) so a remote KV backend can override
+// everything else, matching "remote > file > env > defaults".
+type Loader struct {
+	providers []Provider
+
+	secretResolvers map[string]SecretResolver
+
+	probeBaseURLs bool
+	probeClient   *http.Client
+}
+
+// NewLoader returns a Loader that merges providers in the given order, each
+// one overriding the values of those before it.
+func NewLoader(providers ...Provider) *Loader {
+	return &Loader{providers: providers}
+}
+
+// SetSecretResolvers installs the SecretResolver set resolveSecrets
+// dispatches to, keyed by scheme (see DefaultSecretResolvers). Called
+// post-construction, the same convention kanbanize.Client uses for
+// SetRateLimiter, so a caller that doesn't need secret indirection can just
+// skip it.
+func (l *Loader) SetSecretResolvers(resolvers map[string]SecretResolver) {
+	l.secretResolvers = resolvers
+}
+
+// EnableBaseURLProbe opts this Loader into running ProbeBaseURL against
+// every profile's BaseURL as part of Load, using httpClient (or
+// http.DefaultClient if nil). It's opt-in rather than the default: Load
+// normally only validates syntax, and a flaky network or firewalled
+// sandbox shouldn't turn config loading itself into a point of failure.
+func (l *Loader) EnableBaseURLProbe(httpClient *http.Client) {
+	l.probeBaseURLs = true
+	l.probeClient = httpClient
+}
+
+// Load resolves every provider in order and merges their values, validates
+// the result via fromValues, and reports every problem found — including
+// unrecognized keys a FileProvider parsed out of a config file, which
+// fromValues never sees because mergeValues drops them — on a single
+// *ConfigError rather than stopping at the first one.
+func (l *Loader) Load(ctx context.Context) (*Config, error) {
+	perProvider, err := l.loadEach(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := &ConfigError{}
+	for i, p := range l.providers {
+		fp, ok := p.(FileProvider)
+		if !ok {
+			continue
+		}
+		for key := range perProvider[i] {
+			if !isRelevantKey(key) {
+				errs.add("/"+key, "unrecognized key in %s", fp.Path)
+			}
+		}
+	}
+
+	values, err := l.resolveSecrets(ctx, mergeValues(perProvider))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := fromValues(values)
+	if err != nil {
+		var cfgErr *ConfigError
+		if !errors.As(err, &cfgErr) {
+			return nil, err
+		}
+		errs.Fields = append(errs.Fields, cfgErr.Fields...)
+	}
+	if err := errs.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	if l.probeBaseURLs {
+		probeErrs := &ConfigError{}
+		for name, pc := range cfg.Profiles {
+			if err := ProbeBaseURL(ctx, pc.BaseURL, l.probeClient); err != nil {
+				probeErrs.add("/profiles/"+name+"/base_url", "%s", err)
+			}
+		}
+		if err := probeErrs.errOrNil(); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// resolveSecrets replaces any value of the form "<scheme>:<ref>" with the
+// secret SecretResolvers[scheme] resolves for ref, leaving values whose
+// scheme has no registered resolver untouched (e.g. a plain
+// "https://example.com" base URL, whose "https" scheme is never registered).
+// This runs after merge/mergeValues so a secret reference can itself be
+// overridden between providers like any other value.
+func (l *Loader) resolveSecrets(ctx context.Context, values map[string]string) (map[string]string, error) {
+	if len(l.secretResolvers) == 0 {
+		return values, nil
+	}
+
+	resolved := make(map[string]string, len(values))
+	for key, value := range values {
+		scheme, ref, ok := strings.Cut(value, ":")
+		resolver, hasResolver := l.secretResolvers[scheme]
+		if !ok || !hasResolver {
+			resolved[key] = value
+			continue
+		}
+		secret, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("config: resolving %s: %w", key, err)
+		}
+		resolved[key] = secret
+	}
+	return resolved, nil
+}
+
+// loadEach calls Load on every provider and returns each one's values
+// separately, indexed the same as l.providers. Watch keeps this slice around
+// so that a single provider's change can be re-merged without re-fetching
+// (and potentially racing) every other provider in the chain.
+func (l *Loader) loadEach(ctx context.Context) ([]map[string]string, error) {
+	perProvider := make([]map[string]string, len(l.providers))
+	for i, p := range l.providers {
+		values, err := p.Load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("config: provider %s: %w", p.Name(), err)
+		}
+		perProvider[i] = values
+	}
+	return perProvider, nil
+}
+
+// mergeValues combines a slice of per-provider value maps, indexed in
+// precedence order (later overrides earlier), into one flat map. Only
+// recognized scalar and profile-field keys survive the merge; a provider
+// returning unrelated keys (e.g. a Consul prefix shared with other services)
+// doesn't leak them into the result.
+func mergeValues(perProvider []map[string]string) map[string]string {
+	merged := make(map[string]string, len(scalarKeys))
+	for _, values := range perProvider {
+		for key, v := range values {
+			if v != "" && isRelevantKey(key) {
+				merged[key] = v
+			}
+		}
+	}
+	return merged
+}
+
+// Watch resolves an initial Config the same way Load does, then returns a
+// channel that receives a new *Config snapshot each time a Watcher in the
+// chain reports a change and the re-merged result differs from the last one
+// sent. It lets the MCP server and Kanbanize client pick up a rotated API
+// key or base URL without a restart.
+//
+// If a Watcher's underlying backend becomes unreachable after startup, that
+// provider is expected to keep retrying internally (see ConsulProvider) and
+// simply not send on its channel in the meantime; Watch never replaces a
+// last-good Config with an error, so a remote outage degrades to "stop
+// picking up new changes" rather than "crash the server" or "serve a zero
+// value".
+//
+// The returned channel is closed when ctx is done.
+func (l *Loader) Watch(ctx context.Context) (<-chan *Config, error) {
+	cache, err := l.loadEach(ctx)
+	if err != nil {
+		return nil, err
+	}
+	initialValues, err := l.resolveSecrets(ctx, mergeValues(cache))
+	if err != nil {
+		return nil, err
+	}
+	initial, err := fromValues(initialValues)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *Config, 1)
+
+	// update pairs a freshly-reported value map with the index (into
+	// l.providers/cache) of the provider that produced it, so the merge
+	// below only needs to replace that one provider's slot instead of
+	// re-Load-ing the whole chain (which would race with, and could even
+	// overwrite, the very change being reported).
+	type update struct {
+		idx    int
+		values map[string]string
+	}
+	updates := make(chan update, 1)
+
+	var watching bool
+	for i, p := range l.providers {
+		w, ok := p.(Watcher)
+		if !ok {
+			continue
+		}
+		watching = true
+		i := i
+		changes := make(chan map[string]string, 1)
+		go w.Watch(ctx, changes)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-changes:
+					if !ok {
+						return
+					}
+					select {
+					case updates <- update{idx: i, values: v}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	if !watching {
+		// Nothing in the chain can ever report a change; hand back the
+		// initial snapshot once so callers don't have to special-case a
+		// watch-less chain, then close like any other exhausted Watch.
+		go func() {
+			defer close(out)
+			select {
+			case out <- initial:
+			case <-ctx.Done():
+			}
+		}()
+		return out, nil
+	}
+
+	go func() {
+		defer close(out)
+
+		last := initial
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case u := <-updates:
+				cache[u.idx] = u.values
+				merged, err := l.resolveSecrets(ctx, mergeValues(cache))
+				if err != nil {
+					// A secret reference stopped resolving (e.g. a Vault
+					// lease expired mid-rotation); keep serving last rather
+					// than tearing down the watch.
+					continue
+				}
+				next, err := fromValues(merged)
+				if err != nil {
+					// The changed provider's value, merged with the rest of
+					// the chain, no longer validates (e.g. it just cleared a
+					// required key); keep serving last rather than tearing
+					// down the watch.
+					continue
+				}
+				if valuesEqual(last.values(), next.values()) {
+					continue
+				}
+				last = next
+				select {
+				case out <- next:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func valuesEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
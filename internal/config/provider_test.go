@@ -0,0 +1,197 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type staticProvider struct {
+	name   string
+	values map[string]string
+}
+
+func (p staticProvider) Name() string { return p.name }
+func (p staticProvider) Load(ctx context.Context) (map[string]string, error) {
+	return p.values, nil
+}
+
+func TestLoader_MergePrecedence(t *testing.T) {
+	loader := NewLoader(
+		staticProvider{name: "env", values: map[string]string{
+			"KANBANIZE_API_KEY":  "env-key",
+			"KANBANIZE_BASE_URL": "https://env.example.com",
+		}},
+		staticProvider{name: "file", values: map[string]string{
+			"KANBANIZE_API_KEY": "file-key",
+		}},
+	)
+
+	cfg, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.KanbanizeAPIKey != "file-key" {
+		t.Errorf("expected the later provider (file) to win, got %q", cfg.KanbanizeAPIKey)
+	}
+	if cfg.KanbanizeBaseURL != "https://env.example.com" {
+		t.Errorf("expected the earlier provider's value to survive when not overridden, got %q", cfg.KanbanizeBaseURL)
+	}
+}
+
+func TestLoader_MergePrecedence_ProfileSuffixedKeys(t *testing.T) {
+	loader := NewLoader(
+		staticProvider{name: "env", values: map[string]string{
+			"KANBANIZE_API_KEY":           "default-key",
+			"KANBANIZE_BASE_URL":          "https://default.example.com",
+			"KANBANIZE_API_KEY__staging":  "env-staging-key",
+			"KANBANIZE_BASE_URL__staging": "https://env-staging.example.com",
+		}},
+		staticProvider{name: "file", values: map[string]string{
+			"KANBANIZE_API_KEY__staging": "file-staging-key",
+		}},
+	)
+
+	cfg, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	staging, err := cfg.Profile("staging")
+	if err != nil {
+		t.Fatalf("expected the staging profile to resolve, got %v", err)
+	}
+	if staging.APIKey != "file-staging-key" {
+		t.Errorf("expected the later provider (file) to win for the staging profile, got %q", staging.APIKey)
+	}
+	if staging.BaseURL != "https://env-staging.example.com" {
+		t.Errorf("expected the env provider's base URL to survive when not overridden, got %q", staging.BaseURL)
+	}
+}
+
+func TestLoader_MissingRequiredFieldErrors(t *testing.T) {
+	loader := NewLoader(staticProvider{name: "env", values: map[string]string{
+		"KANBANIZE_API_KEY": "only-key",
+	}})
+
+	if _, err := loader.Load(context.Background()); err == nil {
+		t.Fatal("expected an error when KANBANIZE_BASE_URL is never supplied")
+	}
+}
+
+func TestEnvProvider_Load(t *testing.T) {
+	t.Setenv("KANBANIZE_API_KEY", "from-env")
+	t.Setenv("KANBANIZE_BASE_URL", "https://env.example.com")
+
+	values, err := EnvProvider{}.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if values["KANBANIZE_API_KEY"] != "from-env" {
+		t.Errorf("expected KANBANIZE_API_KEY=from-env, got %q", values["KANBANIZE_API_KEY"])
+	}
+}
+
+func TestFileProvider_Load(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := "# a comment\nKANBANIZE_API_KEY: file-key\nKANBANIZE_BASE_URL: \"https://file.example.com\"\nunrelated_key: ignored\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	values, err := FileProvider{Path: path}.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if values["KANBANIZE_API_KEY"] != "file-key" {
+		t.Errorf("expected KANBANIZE_API_KEY=file-key, got %q", values["KANBANIZE_API_KEY"])
+	}
+	if values["KANBANIZE_BASE_URL"] != "https://file.example.com" {
+		t.Errorf("expected quoted value to be unquoted, got %q", values["KANBANIZE_BASE_URL"])
+	}
+}
+
+func TestFileProvider_MissingFileIsNotAnError(t *testing.T) {
+	values, err := FileProvider{Path: "/nonexistent/path/to/config.yaml"}.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error for a missing file, got %v", err)
+	}
+	if len(values) != 0 {
+		t.Errorf("expected no values from a missing file, got %v", values)
+	}
+}
+
+func TestLoader_Watch_SendsSnapshotOnChange(t *testing.T) {
+	changes := make(chan map[string]string, 1)
+	watcher := &testWatcher{changes: changes}
+
+	loader := NewLoader(
+		staticProvider{name: "env", values: map[string]string{
+			"KANBANIZE_API_KEY":  "initial-key",
+			"KANBANIZE_BASE_URL": "https://example.com",
+		}},
+		watcher,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := loader.Watch(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	changes <- map[string]string{"KANBANIZE_API_KEY": "rotated-key"}
+
+	select {
+	case cfg := <-stream:
+		if cfg.KanbanizeAPIKey != "rotated-key" {
+			t.Errorf("expected the rotated key to win, got %q", cfg.KanbanizeAPIKey)
+		}
+	case <-ctx.Done():
+		t.Fatal("context canceled before a snapshot arrived")
+	}
+}
+
+// testWatcher is a Watcher test double that just relays whatever's sent to
+// its own changes channel, letting a test drive Loader.Watch's merge logic
+// directly instead of standing up a real remote backend.
+type testWatcher struct {
+	changes chan map[string]string
+}
+
+func (w *testWatcher) Name() string { return "test" }
+func (w *testWatcher) Load(ctx context.Context) (map[string]string, error) {
+	return nil, nil
+}
+func (w *testWatcher) Watch(ctx context.Context, out chan<- map[string]string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case v := <-w.changes:
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvSecretResolver_Resolve(t *testing.T) {
+	t.Setenv("SOME_SECRET", "env-secret-value")
+
+	v, err := EnvSecretResolver{}.Resolve(context.Background(), "SOME_SECRET")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if v != "env-secret-value" {
+		t.Errorf("expected env-secret-value, got %q", v)
+	}
+}
+
+func TestEnvSecretResolver_Resolve_UnsetVariableErrors(t *testing.T) {
+	if _, err := (EnvSecretResolver{}).Resolve(context.Background(), "DEFINITELY_NOT_SET"); err == nil {
+		t.Fatal("expected an error resolving an unset environment variable")
+	}
+}
+
+func TestFileSecretResolver_Resolve_PlainFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "api-key")
+	if err := os.WriteFile(path, []byte("mounted-secret\n"), 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	v, err := FileSecretResolver{}.Resolve(context.Background(), path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if v != "mounted-secret" {
+		t.Errorf("expected the trailing newline to be trimmed, got %q", v)
+	}
+}
+
+func TestFileSecretResolver_Resolve_JSONField(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret.json")
+	if err := os.WriteFile(path, []byte(`{"api_key":"json-field-secret","other":"ignored"}`), 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	v, err := FileSecretResolver{}.Resolve(context.Background(), path+"#api_key")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if v != "json-field-secret" {
+		t.Errorf("expected json-field-secret, got %q", v)
+	}
+}
+
+func TestFileSecretResolver_Resolve_MissingFileErrors(t *testing.T) {
+	if _, err := (FileSecretResolver{}).Resolve(context.Background(), "/nonexistent/path/to/secret"); err == nil {
+		t.Fatal("expected an error reading a missing file")
+	}
+}
+
+func TestVaultSecretResolver_Resolve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			t.Errorf("expected the configured token to be sent, got %q", r.Header.Get("X-Vault-Token"))
+		}
+		if r.URL.Path != "/v1/secret/data/kanbanize" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data":{"data":{"api_key":"vault-secret-value"}}}`)
+	}))
+	defer server.Close()
+
+	r := NewVaultSecretResolver(server.URL, "test-token")
+	v, err := r.Resolve(context.Background(), "secret/data/kanbanize#api_key")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if v != "vault-secret-value" {
+		t.Errorf("expected vault-secret-value, got %q", v)
+	}
+}
+
+func TestVaultSecretResolver_Resolve_MissingFieldErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"data":{"other_field":"value"}}}`)
+	}))
+	defer server.Close()
+
+	r := NewVaultSecretResolver(server.URL, "test-token")
+	if _, err := r.Resolve(context.Background(), "secret/data/kanbanize#api_key"); err == nil {
+		t.Fatal("expected an error when the requested field isn't present")
+	}
+}
+
+func TestVaultSecretResolver_RenewSelf(t *testing.T) {
+	var renewed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/auth/token/renew-self" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		renewed = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	r := NewVaultSecretResolver(server.URL, "test-token")
+	if err := r.renewSelf(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !renewed {
+		t.Error("expected the renew-self endpoint to be called")
+	}
+}
+
+func TestAWSSecretsManagerResolver_Resolve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			t.Errorf("unexpected X-Amz-Target %q", r.Header.Get("X-Amz-Target"))
+		}
+		if r.Header.Get("Authorization") == "" {
+			t.Error("expected a SigV4 Authorization header")
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body["SecretId"] != "kanbanize/api-key" {
+			t.Errorf("expected SecretId=kanbanize/api-key, got %q", body["SecretId"])
+		}
+		fmt.Fprint(w, `{"SecretString":"aws-sm-secret-value"}`)
+	}))
+	defer server.Close()
+
+	r := &AWSSecretsManagerResolver{
+		Region:          "us-east-1",
+		AccessKeyID:     "AKIAEXAMPLE",
+		SecretAccessKey: "secretkey",
+		HTTPClient:      server.Client(),
+	}
+	// Resolve always targets the real AWS endpoint for its Region; redirect
+	// it at the fixture instead by overriding the client's transport so the
+	// SigV4 signing logic still runs against the real Host header it builds.
+	r.HTTPClient = &http.Client{Transport: redirectTransport{target: server.URL}}
+
+	v, err := r.Resolve(context.Background(), "//kanbanize/api-key")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if v != "aws-sm-secret-value" {
+		t.Errorf("expected aws-sm-secret-value, got %q", v)
+	}
+}
+
+func TestAWSSecretsManagerResolver_Resolve_FieldExtraction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"SecretString":"{\"api_key\":\"nested-field-value\"}"}`)
+	}))
+	defer server.Close()
+
+	r := &AWSSecretsManagerResolver{
+		Region:          "us-east-1",
+		AccessKeyID:     "AKIAEXAMPLE",
+		SecretAccessKey: "secretkey",
+		HTTPClient:      &http.Client{Transport: redirectTransport{target: server.URL}},
+	}
+
+	v, err := r.Resolve(context.Background(), "//kanbanize/secret#api_key")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if v != "nested-field-value" {
+		t.Errorf("expected nested-field-value, got %q", v)
+	}
+}
+
+// redirectTransport sends every request to target instead of its original
+// host, letting a test point AWSSecretsManagerResolver at an httptest server
+// without changing how it builds its SigV4-signed request (which always
+// targets secretsmanager.<region>.amazonaws.com).
+type redirectTransport struct {
+	target string
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	targetURL, err := http.NewRequest(req.Method, t.target, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	targetURL.Header = req.Header
+	return http.DefaultTransport.RoundTrip(targetURL)
+}
+
+func TestLoader_ResolveSecrets_EnvScheme(t *testing.T) {
+	t.Setenv("INDIRECT_API_KEY", "resolved-from-env")
+
+	loader := NewLoader(staticProvider{name: "env", values: map[string]string{
+		"KANBANIZE_API_KEY":  "env:INDIRECT_API_KEY",
+		"KANBANIZE_BASE_URL": "https://example.com",
+	}})
+	loader.SetSecretResolvers(DefaultSecretResolvers())
+
+	cfg, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.KanbanizeAPIKey != "resolved-from-env" {
+		t.Errorf("expected the env: reference to resolve, got %q", cfg.KanbanizeAPIKey)
+	}
+}
+
+func TestLoader_ResolveSecrets_UnregisteredSchemePassesThrough(t *testing.T) {
+	loader := NewLoader(staticProvider{name: "env", values: map[string]string{
+		"KANBANIZE_API_KEY":  "plain-key",
+		"KANBANIZE_BASE_URL": "https://example.com",
+	}})
+	loader.SetSecretResolvers(DefaultSecretResolvers())
+
+	cfg, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.KanbanizeBaseURL != "https://example.com" {
+		t.Errorf("expected a URL with no registered resolver to pass through unchanged, got %q", cfg.KanbanizeBaseURL)
+	}
+}
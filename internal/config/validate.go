@@ -0,0 +1,168 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// minAPIKeyLength is the shortest value fromValues accepts for a profile's
+// APIKey; it's a sanity floor against pasting an empty quote or a truncated
+// value, not a guess at Businessmap's own key format.
+const minAPIKeyLength = 8
+
+// apiKeyPattern matches the charset Businessmap API keys are documented to
+// use. A key outside it is almost always a copy-paste mistake (stray
+// whitespace, a wrapping quote) rather than a key Businessmap actually
+// issued.
+var apiKeyPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// FieldError describes one invalid field found while validating a Config,
+// identified by a JSON-pointer-style path (e.g. "/profiles/staging/api_key")
+// so a ConfigError can point straight at the offending setting.
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ConfigError aggregates every FieldError found while validating a Config,
+// so fromValues can report every problem in one pass instead of the
+// classic "run, read the first error, set one var, run again" loop.
+type ConfigError struct {
+	Fields []FieldError
+}
+
+func (e *ConfigError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Error()
+	}
+	return fmt.Sprintf("config: %d validation error(s):\n  %s", len(e.Fields), strings.Join(messages, "\n  "))
+}
+
+// add records one field problem. path should be JSON-pointer style, e.g.
+// "/profiles/staging/api_key".
+func (e *ConfigError) add(path, format string, args ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// errOrNil returns e as an error if it collected any fields, sorted by path
+// so repeated runs against the same bad config produce the same output, or
+// nil if validation found nothing wrong.
+func (e *ConfigError) errOrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	sort.Slice(e.Fields, func(i, j int) bool { return e.Fields[i].Path < e.Fields[j].Path })
+	return e
+}
+
+// validateProfile checks one named profile's fields, normalizing BaseURL in
+// place (stripping a trailing slash so callers never have to worry about a
+// doubled "//" when joining a path onto it) and recording every problem
+// found onto errs rather than stopping at the first one.
+func validateProfile(errs *ConfigError, name string, pc *ProfileConfig) {
+	base := "/profiles/" + name
+
+	if pc.APIKey == "" {
+		errs.add(base+"/api_key", "is required")
+	} else {
+		if len(pc.APIKey) < minAPIKeyLength {
+			errs.add(base+"/api_key", "must be at least %d characters", minAPIKeyLength)
+		}
+		if !apiKeyPattern.MatchString(pc.APIKey) {
+			errs.add(base+"/api_key", "must contain only letters, digits, '.', '_', or '-'")
+		}
+	}
+
+	if pc.BaseURL == "" {
+		errs.add(base+"/base_url", "is required")
+	} else if normalized, err := normalizeBaseURL(pc.BaseURL); err != nil {
+		errs.add(base+"/base_url", "%s", err)
+	} else {
+		pc.BaseURL = normalized
+	}
+
+	if pc.RateLimitRPS < 0 {
+		errs.add(base+"/rate_limit_rps", "must not be negative")
+	}
+	if pc.RateLimitBurst < 0 {
+		errs.add(base+"/rate_limit_burst", "must not be negative")
+	}
+	if pc.Timeout < 0 {
+		errs.add(base+"/timeout_ms", "must not be negative")
+	}
+}
+
+// normalizeBaseURL requires an http(s) base URL with a host, and strips any
+// trailing slash so every client built from it can join a path onto it with
+// a single "/" (see kanbanize.NewClient) without ever producing "//".
+func normalizeBaseURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("is not a valid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("must have an http or https scheme, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("is missing a host")
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	return u.String(), nil
+}
+
+// ProbeBaseURL performs an optional preflight reachability check against
+// baseURL + "/api/v2", the root of Businessmap's REST API. It's not run by
+// Load automatically (a misconfigured DNS/firewall shouldn't turn every
+// config reload into a blocking network call); see
+// Loader.EnableBaseURLProbe for opting a Loader into running it as part of
+// Load.
+func ProbeBaseURL(ctx context.Context, baseURL string, httpClient *http.Client) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+"/api/v2", nil)
+	if err != nil {
+		return fmt.Errorf("config: building preflight probe request for %s: %w", baseURL, err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("config: preflight probe for %s: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	// Any response at all, even a 401/404 from a misconfigured path, proves
+	// the host is reachable and speaking HTTP; only a server-side failure
+	// counts as the probe itself failing.
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("config: preflight probe for %s: server returned status %d", baseURL, resp.StatusCode)
+	}
+	return nil
+}
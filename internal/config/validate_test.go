@@ -0,0 +1,137 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFromValues_AggregatesAllFieldErrors(t *testing.T) {
+	_, err := fromValues(map[string]string{
+		"KANBANIZE_API_KEY":  "short",
+		"KANBANIZE_BASE_URL": "not-a-url",
+	})
+	if err == nil {
+		t.Fatal("expected an error for a too-short API key and an invalid base URL")
+	}
+
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) {
+		t.Fatalf("expected a *ConfigError, got %T", err)
+	}
+	if len(cfgErr.Fields) != 2 {
+		t.Fatalf("expected both the api_key and base_url problems reported together, got %d: %v", len(cfgErr.Fields), cfgErr.Fields)
+	}
+}
+
+func TestFromValues_NormalizesBaseURLTrailingSlash(t *testing.T) {
+	cfg, err := fromValues(map[string]string{
+		"KANBANIZE_API_KEY":  "a-valid-key",
+		"KANBANIZE_BASE_URL": "https://example.com/",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.KanbanizeBaseURL != "https://example.com" {
+		t.Errorf("expected the trailing slash to be stripped, got %q", cfg.KanbanizeBaseURL)
+	}
+}
+
+func TestFromValues_RejectsNonHTTPScheme(t *testing.T) {
+	if _, err := fromValues(map[string]string{
+		"KANBANIZE_API_KEY":  "a-valid-key",
+		"KANBANIZE_BASE_URL": "ftp://example.com",
+	}); err == nil {
+		t.Fatal("expected an error for a non-http(s) base URL scheme")
+	}
+}
+
+func TestFromValues_RejectsInvalidAPIKeyCharset(t *testing.T) {
+	if _, err := fromValues(map[string]string{
+		"KANBANIZE_API_KEY":  "has a space!!",
+		"KANBANIZE_BASE_URL": "https://example.com",
+	}); err == nil {
+		t.Fatal("expected an error for an API key containing disallowed characters")
+	}
+}
+
+func TestLoader_Load_ReportsUnrecognizedFileKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := "KANBANIZE_API_KEY: a-valid-key\nKANBANIZE_BASE_URL: https://example.com\nKANBANIZE_TYPO_KEY: oops\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	loader := NewLoader(FileProvider{Path: path})
+	if _, err := loader.Load(context.Background()); err == nil {
+		t.Fatal("expected an error reporting the unrecognized config file key")
+	} else if !strings.Contains(err.Error(), "KANBANIZE_TYPO_KEY") {
+		t.Errorf("expected the error to name the unrecognized key, got %v", err)
+	}
+}
+
+func TestLoader_EnableBaseURLProbe_FailsOnServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	loader := NewLoader(staticProvider{name: "env", values: map[string]string{
+		"KANBANIZE_API_KEY":  "a-valid-key",
+		"KANBANIZE_BASE_URL": server.URL,
+	}})
+	loader.EnableBaseURLProbe(server.Client())
+
+	if _, err := loader.Load(context.Background()); err == nil {
+		t.Fatal("expected the preflight probe to fail against a server returning 500")
+	}
+}
+
+func TestLoader_EnableBaseURLProbe_SucceedsOnReachableHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	loader := NewLoader(staticProvider{name: "env", values: map[string]string{
+		"KANBANIZE_API_KEY":  "a-valid-key",
+		"KANBANIZE_BASE_URL": server.URL,
+	}})
+	loader.EnableBaseURLProbe(server.Client())
+
+	if _, err := loader.Load(context.Background()); err != nil {
+		t.Fatalf("expected a reachable host to pass the probe even with a 404, got %v", err)
+	}
+}
+
+func TestPrintSchema(t *testing.T) {
+	var buf strings.Builder
+	if err := PrintSchema(&buf); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "KANBANIZE_API_KEY") {
+		t.Error("expected the example schema to mention KANBANIZE_API_KEY")
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIError_Retryable(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"unauthorized", false},
+		{"invalid_api_key", false},
+		{"forbidden", false},
+		{"permission_denied", false},
+		{"not_found", false},
+		{"validation_error", false},
+		{"conflict", true},
+		{"", true},
+		{"some_unknown_code", true},
+	}
+	for _, tt := range tests {
+		e := &APIError{Code: tt.code}
+		if got := e.Retryable(); got != tt.want {
+			t.Errorf("APIError{Code: %q}.Retryable() = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func retryConfigForStatusTests() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:  4,
+		InitialDelay: 5 * time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		Multiplier:   2.0,
+		TotalWaitCap: time.Second,
+	}
+}
+
+func TestGetCardsWithRetry_NonRetryableAPIErrorStopsAfterOneAttempt(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		errorCode  string
+	}{
+		{"unauthorized", http.StatusUnauthorized, "unauthorized"},
+		{"forbidden", http.StatusForbidden, "forbidden"},
+		{"not_found", http.StatusNotFound, "not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestCount int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestCount++
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(`{"error": "nope", "error_code": "` + tt.errorCode + `"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-api-key")
+			_, err := client.GetCardsWithRetry(context.Background(), GetCardsRequest{BoardIDs: []int{1}}, retryConfigForStatusTests(), false)
+
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected error to wrap *APIError, got %T: %v", err, err)
+			}
+			if apiErr.Code != tt.errorCode {
+				t.Errorf("expected Code=%q, got %q", tt.errorCode, apiErr.Code)
+			}
+			if requestCount != 1 {
+				t.Errorf("expected exactly 1 request, got %d", requestCount)
+			}
+		})
+	}
+}
+
+func TestGetCardsWithRetry_ConflictAPIErrorRetriesUpToMaxAttempts(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error": "card locked by another update", "error_code": "conflict"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := retryConfigForStatusTests()
+	_, err := client.GetCardsWithRetry(context.Background(), GetCardsRequest{BoardIDs: []int{1}}, cfg, false)
+
+	if err == nil {
+		t.Fatal("expected an error since every attempt returns 409")
+	}
+	if requestCount != cfg.MaxAttempts {
+		t.Errorf("expected %d requests (MaxAttempts), got %d", cfg.MaxAttempts, requestCount)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import "context"
+
+// GetCardsAsync runs GetCardsWithRetry in its own goroutine and reports the
+// result on one of the two returned channels: the response channel on
+// success, the error channel on failure. Exactly one value is sent across
+// the pair, after which both channels are closed, so a caller fanning out
+// several lookups can range over both without risking a leaked goroutine or
+// a double-close. Canceling ctx unblocks the retry loop the same way it
+// would for the synchronous GetCardsWithRetry.
+func (c *Client) GetCardsAsync(ctx context.Context, filter GetCardsRequest, cfg RetryConfig, failOnPartial bool, opts ...RequestOption) (<-chan *GetCardsWithRetryResponse, <-chan error) {
+	respCh := make(chan *GetCardsWithRetryResponse, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(respCh)
+		defer close(errCh)
+
+		resp, err := c.GetCardsWithRetry(ctx, filter, cfg, failOnPartial, opts...)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		respCh <- resp
+	}()
+
+	return respCh, errCh
+}
+
+// ReadCardAsync runs ReadCardWithRetry in its own goroutine and reports the
+// result on one of the two returned channels, following the same
+// exactly-once, both-closed contract as GetCardsAsync.
+func (c *Client) ReadCardAsync(ctx context.Context, cardIDOrURL string, cfg RetryConfig, failOnPartial bool, opts ...RequestOption) (<-chan *ReadCardWithRetryResponse, <-chan error) {
+	respCh := make(chan *ReadCardWithRetryResponse, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(respCh)
+		defer close(errCh)
+
+		resp, err := c.ReadCardWithRetry(ctx, cardIDOrURL, cfg, failOnPartial, opts...)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		respCh <- resp
+	}()
+
+	return respCh, errCh
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetCardsAsync_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"data": {
+				"pagination": {"all_pages": 1, "current_page": 1, "results_per_page": 200},
+				"data": [{"card_id": 101, "title": "Card 1", "board_id": 1, "lane_id": 10, "workflow_id": 100}]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+	cfg := RetryConfig{
+		MaxAttempts:  1,
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+		TotalWaitCap: time.Second,
+	}
+
+	respCh, errCh := client.GetCardsAsync(context.Background(), filter, cfg, false)
+
+	select {
+	case resp, ok := <-respCh:
+		if !ok || resp == nil {
+			t.Fatal("expected a response on the response channel")
+		}
+		if len(resp.Cards) != 1 {
+			t.Errorf("expected 1 card, got %d", len(resp.Cards))
+		}
+	case err := <-errCh:
+		t.Fatalf("expected no error, got %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for GetCardsAsync result")
+	}
+
+	if _, ok := <-errCh; ok {
+		t.Error("expected the error channel to be closed after a successful result")
+	}
+}
+
+func TestGetCardsAsync_Error(t *testing.T) {
+	client := NewClient("http://example.com", "test-api-key")
+
+	respCh, errCh := client.GetCardsAsync(context.Background(), GetCardsRequest{}, DefaultRetryConfig(), false)
+
+	select {
+	case resp, ok := <-respCh:
+		t.Fatalf("expected no response, got %v (ok=%v)", resp, ok)
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("expected a non-nil error for an empty filter")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for GetCardsAsync result")
+	}
+
+	if _, ok := <-respCh; ok {
+		t.Error("expected the response channel to be closed after an error result")
+	}
+}
+
+func TestReadCardAsync_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/cards/2001":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": {"card_id": 2001, "title": "Async Card"}}`))
+		case r.URL.Path == "/api/v2/cards/2001/comments":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": []}`))
+		case r.URL.Path == "/api/v2/cards/2001/subtasks":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := RetryConfig{
+		MaxAttempts:  1,
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+		TotalWaitCap: time.Second,
+	}
+
+	respCh, errCh := client.ReadCardAsync(context.Background(), "2001", cfg, false)
+
+	select {
+	case resp, ok := <-respCh:
+		if !ok || resp == nil {
+			t.Fatal("expected a response on the response channel")
+		}
+		if resp.Data.Title != "Async Card" {
+			t.Errorf("expected title 'Async Card', got %q", resp.Data.Title)
+		}
+	case err := <-errCh:
+		t.Fatalf("expected no error, got %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ReadCardAsync result")
+	}
+}
+
+func TestReadCardAsync_CanceledContextSurfacesError(t *testing.T) {
+	client := NewClient("http://example.com", "test-api-key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	respCh, errCh := client.ReadCardAsync(ctx, "2001", DefaultRetryConfig(), false)
+
+	select {
+	case resp, ok := <-respCh:
+		t.Fatalf("expected no response, got %v (ok=%v)", resp, ok)
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("expected a non-nil error for a canceled context")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ReadCardAsync result")
+	}
+}
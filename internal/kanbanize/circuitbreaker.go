@@ -0,0 +1,131 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"net/url"
+	"sync"
+	"time"
+)
+
+// circuitState is the state of a single host's circuit breaker.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker trips after a run of consecutive failures against a single
+// host, short-circuiting further calls until a cool-down elapses. It then
+// turns half-open and lets calls through without a limit on how many; the
+// first recorded success closes the breaker again, the first recorded
+// failure reopens it for another cool-down period.
+type circuitBreaker struct {
+	mu                  sync.Mutex
+	state               circuitState
+	threshold           int
+	cooldown            time.Duration
+	consecutiveFailures int
+	openedAt            time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		state:     circuitClosed,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow reports whether a call should proceed, transitioning an open breaker
+// to half-open once the cool-down has elapsed.
+func (cb *circuitBreaker) allow() (bool, time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		coolsDown := cb.openedAt.Add(cb.cooldown)
+		if time.Now().Before(coolsDown) {
+			return false, coolsDown
+		}
+		cb.state = circuitHalfOpen
+		return true, time.Time{}
+	default:
+		return true, time.Time{}
+	}
+}
+
+func (cb *circuitBreaker) recordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.consecutiveFailures = 0
+	cb.state = circuitClosed
+}
+
+func (cb *circuitBreaker) recordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == circuitHalfOpen {
+		// The probe failed; go straight back to open for another cool-down.
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+		return
+	}
+
+	cb.consecutiveFailures++
+	if cb.threshold > 0 && cb.consecutiveFailures >= cb.threshold {
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+	}
+}
+
+// currentState reports the breaker's current state, e.g. for exporting as a
+// circuit_state metric gauge.
+func (cb *circuitBreaker) currentState() circuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// circuitBreakerFor returns the breaker tracking rawURL's host, creating one
+// on first use. Breakers are scoped per-host so a dead BusinessMap instance
+// doesn't trip retries against an unrelated one.
+func (c *Client) circuitBreakerFor(rawURL string) (*circuitBreaker, string) {
+	host := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+
+	c.breakersMu.Lock()
+	defer c.breakersMu.Unlock()
+
+	if c.breakers == nil {
+		c.breakers = make(map[string]*circuitBreaker)
+	}
+
+	cb, ok := c.breakers[host]
+	if !ok {
+		cb = newCircuitBreaker(c.circuitThreshold, c.circuitCooldown)
+		c.breakers[host] = cb
+	}
+	return cb, host
+}
@@ -26,23 +26,223 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/hivemq/businessmap-mcp/internal/kanbanize/query"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Default circuit-breaker tuning used unless overridden via WithCircuitBreaker.
+const (
+	defaultCircuitThreshold = 5
+	defaultCircuitCooldown  = 30 * time.Second
 )
 
 type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+
+	circuitThreshold int
+	circuitCooldown  time.Duration
+	breakersMu       sync.Mutex
+	breakers         map[string]*circuitBreaker
+
+	metrics *clientMetrics
+	tracer  trace.Tracer
+
+	rateLimits *RateLimitMap
+
+	retryTokens           *retryTokenBucket
+	retryTokenSuccessRate float64
+
+	idempotency *idempotencyCache
+
+	clock Clock
+
+	timeParser   TimeParser
+	onParseError TimestampPolicy
+
+	rateLimiter RateLimiter
+
+	onRetry func(AttemptRecord)
+}
+
+// Option configures optional Client behavior at construction time.
+type Option func(*Client)
+
+// WithHTTPClient overrides the http.Client used for all requests, e.g. to
+// install a custom Transport or reuse an http.Client shared across services.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout overrides the default 30s request timeout by setting Timeout on
+// the client's current http.Client. Options apply in order: placed before
+// WithHTTPClient it has no effect, since that option replaces the client
+// wholesale; placed after it, it sets Timeout on the caller's http.Client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithCircuitBreaker overrides the per-host circuit breaker tuning: it trips
+// after threshold consecutive failures and stays open for cooldown before
+// allowing a half-open probe request through.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.circuitThreshold = threshold
+		c.circuitCooldown = cooldown
+	}
+}
+
+// WithMetrics registers Prometheus collectors for request counts, latency,
+// rate-limit hits, retry attempts, and circuit breaker state against reg.
+// Without this option, instrumentation is a no-op.
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(c *Client) {
+		c.metrics = newClientMetrics(reg)
+	}
+}
+
+// WithTracer enables OpenTelemetry spans around every outbound HTTP attempt,
+// using tp to create a tracer scoped to this package. Without this option,
+// no spans are created.
+func WithTracer(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// WithRetryBudget overrides the per-Client retry token bucket that
+// makeRequestWithRetry must draw from before scheduling a backoff wait:
+// capacity tokens refilled at refillPerSecond tokens/sec, with successRate
+// (0-1) of successful requests crediting a token back early. Without this
+// option, the client uses defaultRetryTokenCapacity/defaultRetryTokenRefillRate/
+// defaultRetryTokenSuccessRate.
+func WithRetryBudget(capacity int, refillPerSecond, successRate float64) Option {
+	return func(c *Client) {
+		c.retryTokens = newRetryTokenBucket(float64(capacity), refillPerSecond)
+		c.retryTokenSuccessRate = successRate
+	}
+}
+
+// WithClock overrides the Clock the retry loops use for timestamps and
+// backoff sleeps, letting tests advance time deterministically instead of
+// sleeping in real time. Without this option, the client uses the real
+// wall clock.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithIdempotencyCache overrides the capacity and TTL of the in-memory cache
+// used to replay mutating calls made with option.WithIdempotencyKey or
+// option.WithAutoIdempotency. Without this option, the client uses a cache
+// of defaultIdempotencyCapacity entries with a TTL of defaultIdempotencyTTL.
+func WithIdempotencyCache(capacity int, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.idempotency = newIdempotencyCache(capacity, ttl)
+	}
+}
+
+// WithTimeFormats overrides the layouts tried, in order, when parsing a card
+// or comment timestamp, e.g. "2006-01-02 15:04:05 -0700" for a tenant that
+// sends its own UTC offset. It has no effect when combined with
+// WithTimeParser, since that option replaces the parser wholesale.
+func WithTimeFormats(formats ...string) Option {
+	return func(c *Client) {
+		if p, ok := c.timeParser.(*defaultTimeParser); ok {
+			p.formats = formats
+		}
+	}
+}
+
+// WithTimeLocation sets the location a naive (no UTC offset) timestamp layout
+// is interpreted in; without this option, naive timestamps are read as UTC.
+// It has no effect when combined with WithTimeParser, since that option
+// replaces the parser wholesale.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(c *Client) {
+		if p, ok := c.timeParser.(*defaultTimeParser); ok {
+			p.location = loc
+		}
+	}
+}
+
+// WithTimeParser replaces the TimeParser used for every card and comment
+// timestamp, for an operator whose tenant needs parsing logic WithTimeFormats
+// and WithTimeLocation can't express. Without this option, the client uses
+// its built-in defaultTimeParser.
+func WithTimeParser(parser TimeParser) Option {
+	return func(c *Client) {
+		c.timeParser = parser
+	}
+}
+
+// WithOnParseError sets the policy applied when a card timestamp field fails
+// to parse: PolicyNilOut (the default) leaves the field nil, PolicyKeepRaw
+// additionally records the raw string on ReadCardResponse.RawTimestamps, and
+// PolicyError fails the whole ReadCard/ReadCardWithRetry call.
+func WithOnParseError(policy TimestampPolicy) Option {
+	return func(c *Client) {
+		c.onParseError = policy
+	}
 }
 
-func NewClient(baseURL, apiKey string) *Client {
-	return &Client{
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
 		baseURL: strings.TrimSuffix(baseURL, "/"),
 		apiKey:  apiKey,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		circuitThreshold:      defaultCircuitThreshold,
+		circuitCooldown:       defaultCircuitCooldown,
+		rateLimits:            newRateLimitMap(),
+		retryTokens:           newRetryTokenBucket(defaultRetryTokenCapacity, defaultRetryTokenRefillRate),
+		retryTokenSuccessRate: defaultRetryTokenSuccessRate,
+		idempotency:           newIdempotencyCache(defaultIdempotencyCapacity, defaultIdempotencyTTL),
+		clock:                 realClock{},
+		timeParser:            newDefaultTimeParser(),
+		onParseError:          PolicyNilOut,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// SetRateLimiter installs limiter so every outgoing request made by the
+// retry loops (makeRequestWithRetry, fetchWithRetry, fetchWithRetryShared —
+// all of which funnel through doRequest) waits on it before being sent, so a
+// caller can stay under BusinessMap's published quotas proactively instead
+// of only reacting to 429s after the fact. It composes with
+// RetryConfig.RespectRetryAfter: limiter throttles before a request goes
+// out, RespectRetryAfter governs backing off once the server has already
+// said no. Without a call to SetRateLimiter, no proactive limiting happens.
+func (c *Client) SetRateLimiter(limiter RateLimiter) {
+	c.rateLimiter = limiter
+}
+
+// OnRetry installs fn to be called, synchronously and in order, once for
+// every HTTP attempt made by the retry loops (makeRequestWithRetry,
+// fetchWithRetry, fetchWithRetryShared), whether it succeeded or failed. This
+// is the live counterpart to the AttemptRecord slices returned on
+// GetCardsWithRetryResponse.RetryTrace and ReadCardWithRetryResponse.RetryTrace
+// — use OnRetry to stream attempt detail as it happens (e.g. into a metrics
+// pipeline), and RetryTrace to inspect the full history of one completed
+// call. Passing nil disables the hook.
+func (c *Client) OnRetry(fn func(AttemptRecord)) {
+	c.onRetry = fn
 }
 
 // extractCardID extracts card ID from either a card ID or a full BusinessMap URL
@@ -72,93 +272,151 @@ func (c *Client) extractCardID(input string) (string, error) {
 	return matches[1], nil
 }
 
-// parseTimestamp parses an RFC3339 timestamp string into a time.Time pointer
-func parseTimestamp(ts *string) *time.Time {
-	if ts == nil || *ts == "" {
-		return nil
-	}
-	if parsed, err := time.Parse(time.RFC3339, *ts); err == nil {
-		return &parsed
+// timestampField pairs a CardData raw timestamp string with the
+// ReadCardResponse field it should populate, so populateTimestamps can apply
+// c.timeParser and c.onParseError once across every timestamp on a card
+// instead of repeating the same parse-and-apply-policy dance per field.
+type timestampField struct {
+	name string
+	raw  *string
+	dst  **time.Time
+}
+
+// populateTimestamps parses every field's raw string with c.timeParser and
+// assigns the result to *field.dst, applying c.onParseError to whatever
+// fields fail to parse. It returns a non-nil error only under PolicyError.
+func (c *Client) populateTimestamps(response *ReadCardResponse, fields []timestampField) error {
+	for _, field := range fields {
+		if field.raw == nil || *field.raw == "" {
+			continue
+		}
+
+		parsed, err := c.timeParser.Parse(*field.raw)
+		if err == nil {
+			*field.dst = parsed
+			continue
+		}
+
+		switch c.onParseError {
+		case PolicyKeepRaw:
+			if response.RawTimestamps == nil {
+				response.RawTimestamps = make(map[string]string)
+			}
+			response.RawTimestamps[field.name] = *field.raw
+		case PolicyError:
+			return fmt.Errorf("failed to parse %s timestamp %q: %w", field.name, *field.raw, err)
+		default: // PolicyNilOut
+		}
 	}
 	return nil
 }
 
-// parseCommentTimestamp tries multiple date formats to parse comment timestamps
-func parseCommentTimestamp(dateStr string) time.Time {
-	if dateStr == "" {
-		return time.Time{}
+// cardTimestampFields lists every CardData timestamp alongside the
+// ReadCardResponse field it feeds, for use with populateTimestamps.
+func cardTimestampFields(cardData *CardData, response *ReadCardResponse) []timestampField {
+	fields := []timestampField{
+		{"created_at", cardData.CreatedAt, &response.CreatedAt},
+		{"last_modified", cardData.LastModified, &response.LastModified},
+		{"in_current_position_since", cardData.InCurrentPositionSince, &response.InCurrentPositionSince},
+		{"first_request_time", cardData.FirstRequestTime, &response.FirstRequestTime},
+		{"first_start_time", cardData.FirstStartTime, &response.FirstStartTime},
+		{"first_end_time", cardData.FirstEndTime, &response.FirstEndTime},
+		{"last_request_time", cardData.LastRequestTime, &response.LastRequestTime},
+		{"last_start_time", cardData.LastStartTime, &response.LastStartTime},
+		{"last_end_time", cardData.LastEndTime, &response.LastEndTime},
 	}
-
-	// Try common formats
-	formats := []string{
-		time.RFC3339,           // "2006-01-02T15:04:05Z07:00"
-		"2006-01-02T15:04:05Z", // RFC3339 without timezone offset
-		"2006-01-02 15:04:05",  // Space-separated format
-		"2006-01-02T15:04:05",  // T-separated without timezone
-		time.RFC3339Nano,       // With nanoseconds
+	if cardData.InitiativeDetails != nil {
+		fields = append(fields,
+			timestampField{"actual_start_time", cardData.InitiativeDetails.ActualStartTime, &response.ActualStartTime},
+			timestampField{"actual_end_time", cardData.InitiativeDetails.ActualEndTime, &response.ActualEndTime},
+		)
 	}
+	return fields
+}
 
-	for _, format := range formats {
-		if parsed, err := time.Parse(format, dateStr); err == nil {
-			return parsed
-		}
+// parseCommentTimestamp parses a comment's CreatedAt with c.timeParser,
+// returning the zero time.Time if it doesn't parse: unlike card timestamps, a
+// comment has no nilable field or RawTimestamps to fall back to, so it keeps
+// the package's original silent-failure behavior regardless of
+// c.onParseError.
+func (c *Client) parseCommentTimestamp(dateStr string) time.Time {
+	parsed, err := c.timeParser.Parse(dateStr)
+	if err != nil || parsed == nil {
+		return time.Time{}
 	}
+	return *parsed
+}
 
-	// If all parsing fails, return zero time
-	return time.Time{}
+// ReadCard fetches a card's full details. It delegates to ReadCardContext
+// using context.Background(); prefer ReadCardContext when a deadline or
+// cancellation signal is available.
+func (c *Client) ReadCard(cardIDOrURL string, opts ...RequestOption) (*ReadCardResponse, error) {
+	return c.ReadCardContext(context.Background(), cardIDOrURL, opts...)
 }
 
-func (c *Client) ReadCard(cardIDOrURL string) (*ReadCardResponse, error) {
+// ReadCardContext fetches a card's full details, propagating ctx through
+// every outbound HTTP request so callers can bound the overall call with a
+// deadline or cancel it early. opts may override per-call behavior such as
+// the API key or base URL without affecting the Client's other callers; see
+// the option package.
+func (c *Client) ReadCardContext(ctx context.Context, cardIDOrURL string, opts ...RequestOption) (*ReadCardResponse, error) {
+	ctx = withRequestOptions(ctx, opts...)
+
 	cardID, err := c.extractCardID(cardIDOrURL)
 	if err != nil {
 		return nil, err
 	}
 
-	cardData, err := c.getCard(cardID)
+	cardData, err := c.getCardContext(ctx, cardID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get card data: %w", err)
 	}
 
-	comments, err := c.getCardComments(cardID)
+	comments, err := c.getCardCommentsContext(ctx, cardID)
 	if err != nil {
 		comments = []Comment{}
 	}
 
-	subtasks, err := c.getCardSubtasks(cardID)
+	subtasks, err := c.getCardSubtasksContext(ctx, cardID)
 	if err != nil {
 		subtasks = []Subtask{}
 	}
 
 	response := &ReadCardResponse{
-		Title:                  cardData.Title,
-		Description:            cardData.Description,
-		Comments:               comments,
-		Subtasks:               subtasks,
-		LinkedCards:            cardData.LinkedCards,
-		CustomFields:           cardData.CustomFields,
-		CreatedAt:              parseTimestamp(cardData.CreatedAt),
-		LastModified:           parseTimestamp(cardData.LastModified),
-		InCurrentPositionSince: parseTimestamp(cardData.InCurrentPositionSince),
-		FirstRequestTime:       parseTimestamp(cardData.FirstRequestTime),
-		FirstStartTime:         parseTimestamp(cardData.FirstStartTime),
-		FirstEndTime:           parseTimestamp(cardData.FirstEndTime),
-		LastRequestTime:        parseTimestamp(cardData.LastRequestTime),
-		LastStartTime:          parseTimestamp(cardData.LastStartTime),
-		LastEndTime:            parseTimestamp(cardData.LastEndTime),
+		Title:        cardData.Title,
+		Description:  cardData.Description,
+		Comments:     comments,
+		Subtasks:     subtasks,
+		LinkedCards:  cardData.LinkedCards,
+		CustomFields: cardData.CustomFields,
 	}
 
 	// Parse initiative details if present
 	if cardData.InitiativeDetails != nil {
 		response.PlannedStartDate = cardData.InitiativeDetails.PlannedStartDate
 		response.PlannedEndDate = cardData.InitiativeDetails.PlannedEndDate
-		response.ActualStartTime = parseTimestamp(cardData.InitiativeDetails.ActualStartTime)
-		response.ActualEndTime = parseTimestamp(cardData.InitiativeDetails.ActualEndTime)
+	}
+
+	if err := c.populateTimestamps(response, cardTimestampFields(cardData, response)); err != nil {
+		return nil, fmt.Errorf("failed to get card data: %w", err)
 	}
 
 	return response, nil
 }
 
-func (c *Client) AddCardComment(cardIDOrURL, text string) error {
+// AddCardComment adds a comment to a card. It delegates to
+// AddCardCommentContext using context.Background().
+func (c *Client) AddCardComment(cardIDOrURL, text string, opts ...RequestOption) error {
+	return c.AddCardCommentContext(context.Background(), cardIDOrURL, text, opts...)
+}
+
+// AddCardCommentContext adds a comment to a card, propagating ctx through
+// the outbound HTTP request. opts may override per-call behavior such as the
+// API key or base URL without affecting the Client's other callers; see the
+// option package.
+func (c *Client) AddCardCommentContext(ctx context.Context, cardIDOrURL, text string, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts...)
+
 	cardID, err := c.extractCardID(cardIDOrURL)
 	if err != nil {
 		return err
@@ -167,10 +425,21 @@ func (c *Client) AddCardComment(cardIDOrURL, text string) error {
 		return fmt.Errorf("comment text cannot be empty")
 	}
 
-	url := fmt.Sprintf("%s/api/v2/cards/%s/comments", c.baseURL, cardID)
+	url := fmt.Sprintf("%s/api/v2/cards/%s/comments", c.baseURLFor(ctx), cardID)
 	request := AddCommentRequest{Text: text}
 
-	body, err := c.makeAPIRequestWithBody("POST", url, request)
+	idempotencyKey := idempotencyKeyFor(ctx)
+	if idempotencyKey != "" {
+		if entry, ok := c.idempotency.get(idempotencyKey); ok {
+			if entry.contentMismatch([]byte(text)) {
+				return fmt.Errorf("idempotency key %q was already used for a different comment; choose a new key per distinct request", idempotencyKey)
+			}
+			var replayed AddCommentResponse
+			return json.Unmarshal(entry.body, &replayed)
+		}
+	}
+
+	body, err := c.makeRequestWithRetry(ctx, retryConfigFor(ctx, defaultClientRetryConfig()), "POST", url, request)
 	if err != nil {
 		return fmt.Errorf("failed to add comment: %w", err)
 	}
@@ -180,13 +449,21 @@ func (c *Client) AddCardComment(cardIDOrURL, text string) error {
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	if idempotencyKey != "" {
+		c.idempotency.put(idempotencyKey, http.StatusOK, body, []byte(text))
+	}
+
 	return nil
 }
 
 func (c *Client) getCard(cardID string) (*CardData, error) {
-	url := fmt.Sprintf("%s/api/v2/cards/%s", c.baseURL, cardID)
+	return c.getCardContext(context.Background(), cardID)
+}
+
+func (c *Client) getCardContext(ctx context.Context, cardID string) (*CardData, error) {
+	url := fmt.Sprintf("%s/api/v2/cards/%s", c.baseURLFor(ctx), cardID)
 
-	body, err := c.makeAPIRequest(url)
+	body, err := c.makeRequestWithRetry(ctx, retryConfigFor(ctx, defaultClientRetryConfig()), "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -200,9 +477,13 @@ func (c *Client) getCard(cardID string) (*CardData, error) {
 }
 
 func (c *Client) getCardComments(cardID string) ([]Comment, error) {
-	url := fmt.Sprintf("%s/api/v2/cards/%s/comments", c.baseURL, cardID)
+	return c.getCardCommentsContext(context.Background(), cardID)
+}
+
+func (c *Client) getCardCommentsContext(ctx context.Context, cardID string) ([]Comment, error) {
+	url := fmt.Sprintf("%s/api/v2/cards/%s/comments", c.baseURLFor(ctx), cardID)
 
-	body, err := c.makeAPIRequest(url)
+	body, err := c.makeRequestWithRetry(ctx, retryConfigFor(ctx, defaultClientRetryConfig()), "GET", url, nil)
 	if err != nil {
 		return []Comment{}, nil
 	}
@@ -218,7 +499,7 @@ func (c *Client) getCardComments(cardID string) ([]Comment, error) {
 			ID:        strconv.Itoa(commentData.CommentID),
 			Text:      commentData.Text,
 			Author:    commentData.AuthorName,
-			CreatedAt: parseCommentTimestamp(commentData.CreatedAt),
+			CreatedAt: c.parseCommentTimestamp(commentData.CreatedAt),
 		}
 	}
 
@@ -226,9 +507,13 @@ func (c *Client) getCardComments(cardID string) ([]Comment, error) {
 }
 
 func (c *Client) getCardSubtasks(cardID string) ([]Subtask, error) {
-	url := fmt.Sprintf("%s/api/v2/cards/%s/subtasks", c.baseURL, cardID)
+	return c.getCardSubtasksContext(context.Background(), cardID)
+}
 
-	body, err := c.makeAPIRequest(url)
+func (c *Client) getCardSubtasksContext(ctx context.Context, cardID string) ([]Subtask, error) {
+	url := fmt.Sprintf("%s/api/v2/cards/%s/subtasks", c.baseURLFor(ctx), cardID)
+
+	body, err := c.makeRequestWithRetry(ctx, retryConfigFor(ctx, defaultClientRetryConfig()), "GET", url, nil)
 	if err != nil {
 		return []Subtask{}, nil
 	}
@@ -251,28 +536,48 @@ func (c *Client) getCardSubtasks(cardID string) ([]Subtask, error) {
 	return subtasks, nil
 }
 
-func (c *Client) makeAPIRequest(url string) ([]byte, error) {
-	return c.makeAPIRequestWithBody("GET", url, nil)
-}
-
 // GetCardsWithRetry queries multiple cards with retry logic for rate limiting
-// It returns a structured response with metadata about retry attempts
-func (c *Client) GetCardsWithRetry(ctx context.Context, filter GetCardsRequest, cfg RetryConfig, failOnPartial bool) (*GetCardsWithRetryResponse, error) {
+// It returns a structured response with metadata about retry attempts. opts
+// may override per-call behavior such as the API key, base URL, or retry
+// budget without affecting the Client's other callers; see the option
+// package.
+func (c *Client) GetCardsWithRetry(ctx context.Context, filter GetCardsRequest, cfg RetryConfig, failOnPartial bool, opts ...RequestOption) (*GetCardsWithRetryResponse, error) {
+	ctx = withRequestOptions(ctx, opts...)
+	cfg = retryConfigFor(ctx, cfg)
+
 	// Validate at least one filter is provided
 	if len(filter.BoardIDs) == 0 && len(filter.LaneIDs) == 0 &&
-		len(filter.WorkflowIDs) == 0 && len(filter.CardIDs) == 0 {
-		return nil, fmt.Errorf("at least one filter parameter (board_ids, lane_ids, workflow_ids, or card_ids) must be provided")
+		len(filter.WorkflowIDs) == 0 && len(filter.CardIDs) == 0 && filter.Query == "" {
+		return nil, fmt.Errorf("at least one filter parameter (board_ids, lane_ids, workflow_ids, card_ids, or query) must be provided")
 	}
 
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid retry config: %w", err)
 	}
 
-	startTime := time.Now()
+	var predicate func(map[string]interface{}) bool
+	if filter.Query != "" {
+		node, err := query.Parse(filter.Query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+		compiled, err := query.Compile(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+		filter.BoardIDs = mergeUnique(filter.BoardIDs, compiled.BoardIDs)
+		filter.LaneIDs = mergeUnique(filter.LaneIDs, compiled.LaneIDs)
+		filter.WorkflowIDs = mergeUnique(filter.WorkflowIDs, compiled.WorkflowIDs)
+		filter.CardIDs = mergeUnique(filter.CardIDs, compiled.CardIDs)
+		predicate = compiled.Predicate
+	}
+
+	startTime := c.clock.Now()
 	response := &GetCardsWithRetryResponse{
 		Attempts:     make(map[string]int),
 		Completed:    make(map[string]bool),
 		PartialError: make(map[string]string),
+		Reasons:      make(map[string]string),
 		Cards:        []CardSummary{},
 	}
 
@@ -292,7 +597,51 @@ func (c *Client) GetCardsWithRetry(ctx context.Context, filter GetCardsRequest,
 	}
 
 	// Build the URL with query parameters
-	url := fmt.Sprintf("%s/api/v2/cards", c.baseURL)
+	url := fmt.Sprintf("%s/api/v2/cards", c.baseURLFor(ctx))
+	queryParams := cardsFilterQueryParams(filter)
+
+	if len(queryParams) > 0 {
+		url += "?" + strings.Join(queryParams, "&")
+	}
+
+	// Fetch cards with retry
+	cardsResult := c.fetchWithRetry(ctx, cfg, "cards", url)
+	response.Attempts["cards"] = cardsResult.attempts
+	response.RateLimitHits = cardsResult.rateLimitHits
+	response.Completed["cards"] = cardsResult.success
+	response.Reasons["cards"] = string(cardsResult.reason)
+	response.RetryTrace = cardsResult.trace
+
+	if !cardsResult.success {
+		response.PartialError["cards"] = cardsResult.err.Error()
+		response.WaitSeconds = c.clock.Now().Sub(startTime).Seconds()
+		return response, fmt.Errorf("failed to fetch cards: %w", cardsResult.err)
+	}
+
+	// Parse cards data - the API returns nested structure: data.pagination and data.data
+	var cardsResp GetCardsResponse
+	if err := json.Unmarshal(cardsResult.data, &cardsResp); err != nil {
+		// Include raw data in error for debugging
+		return response, fmt.Errorf("failed to parse cards data: %w (raw: %s)", err, string(cardsResult.data))
+	}
+
+	response.Cards = cardsResp.Data.Data
+	if predicate != nil {
+		filtered := make([]CardSummary, 0, len(response.Cards))
+		for _, card := range response.Cards {
+			if predicate(cardSummaryRecord(card)) {
+				filtered = append(filtered, card)
+			}
+		}
+		response.Cards = filtered
+	}
+	response.WaitSeconds = c.clock.Now().Sub(startTime).Seconds()
+	return response, nil
+}
+
+// cardsFilterQueryParams renders filter's typed ID lists into GET /api/v2/cards
+// query parameters, shared by GetCardsWithRetry and GetCardsStream.
+func cardsFilterQueryParams(filter GetCardsRequest) []string {
 	queryParams := []string{}
 
 	if len(filter.BoardIDs) > 0 {
@@ -327,81 +676,241 @@ func (c *Client) GetCardsWithRetry(ctx context.Context, filter GetCardsRequest,
 		queryParams = append(queryParams, "card_ids="+strings.Join(cardIDs, ","))
 	}
 
-	if len(queryParams) > 0 {
-		url += "?" + strings.Join(queryParams, "&")
+	if filter.ModifiedSince != nil {
+		queryParams = append(queryParams, "modified_from_date="+filter.ModifiedSince.UTC().Format(time.RFC3339))
 	}
 
-	// Fetch cards with retry
-	cardsResult := c.fetchWithRetry(ctx, cfg, "cards", url)
-	response.Attempts["cards"] = cardsResult.attempts
-	response.RateLimitHits = cardsResult.rateLimitHits
-	response.Completed["cards"] = cardsResult.success
+	return queryParams
+}
 
-	if !cardsResult.success {
-		response.PartialError["cards"] = cardsResult.err.Error()
-		response.WaitSeconds = time.Since(startTime).Seconds()
-		return response, fmt.Errorf("failed to fetch cards: %w", cardsResult.err)
+// mergeUnique appends b's elements to a, skipping any already present in a.
+func mergeUnique(a, b []int) []int {
+	if len(b) == 0 {
+		return a
+	}
+	seen := make(map[int]bool, len(a))
+	for _, v := range a {
+		seen[v] = true
+	}
+	for _, v := range b {
+		if !seen[v] {
+			a = append(a, v)
+			seen[v] = true
+		}
 	}
+	return a
+}
 
-	// Parse cards data - the API returns nested structure: data.pagination and data.data
-	var cardsResp GetCardsResponse
-	if err := json.Unmarshal(cardsResult.data, &cardsResp); err != nil {
-		// Include raw data in error for debugging
-		return response, fmt.Errorf("failed to parse cards data: %w (raw: %s)", err, string(cardsResult.data))
+// GetCardsStream pages through GET /api/v2/cards instead of buffering the
+// whole filtered result set like GetCardsWithRetry does, so boards with
+// thousands of cards don't have to sit in memory at once. Each page is
+// fetched with the same retry policy as GetCardsWithRetry; after each page
+// is fetched and parsed successfully, emit is called with its progress so a
+// caller can report it onward (e.g. as an MCP progress notification) without
+// GetCardsStream itself knowing anything about MCP. A page that fails is not
+// emitted. It returns the accumulated cards plus the next page to fetch: 0
+// once the API reports no more pages, otherwise the page after the last one
+// fetched when opts.MaxPages is reached, or the page that failed. A caller
+// can resume a previously interrupted stream by calling again with StartPage
+// set to that value.
+func (c *Client) GetCardsStream(ctx context.Context, filter GetCardsRequest, cfg RetryConfig, opts CardStreamOptions, emit func(CardStreamProgress)) (cards []CardSummary, nextPage int, err error) {
+	if len(filter.BoardIDs) == 0 && len(filter.LaneIDs) == 0 &&
+		len(filter.WorkflowIDs) == 0 && len(filter.CardIDs) == 0 && filter.Query == "" {
+		return nil, 0, fmt.Errorf("at least one filter parameter (board_ids, lane_ids, workflow_ids, card_ids, or query) must be provided")
 	}
 
-	response.Cards = cardsResp.Data.Data
-	response.WaitSeconds = time.Since(startTime).Seconds()
-	return response, nil
+	if err := cfg.Validate(); err != nil {
+		return nil, 0, fmt.Errorf("invalid retry config: %w", err)
+	}
+
+	var predicate func(map[string]interface{}) bool
+	if filter.Query != "" {
+		node, err := query.Parse(filter.Query)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid query: %w", err)
+		}
+		compiled, err := query.Compile(node)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid query: %w", err)
+		}
+		filter.BoardIDs = mergeUnique(filter.BoardIDs, compiled.BoardIDs)
+		filter.LaneIDs = mergeUnique(filter.LaneIDs, compiled.LaneIDs)
+		filter.WorkflowIDs = mergeUnique(filter.WorkflowIDs, compiled.WorkflowIDs)
+		filter.CardIDs = mergeUnique(filter.CardIDs, compiled.CardIDs)
+		predicate = compiled.Predicate
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	page := opts.StartPage
+	if page <= 0 {
+		page = 1
+	}
+
+	queryParams := cardsFilterQueryParams(filter)
+	cumulative := 0
+	pagesFetched := 0
+
+	for {
+		if opts.MaxPages > 0 && pagesFetched >= opts.MaxPages {
+			return cards, page, nil
+		}
+
+		pageParams := append(append([]string{}, queryParams...),
+			"page="+strconv.Itoa(page), "per_page="+strconv.Itoa(pageSize))
+		url := fmt.Sprintf("%s/api/v2/cards?%s", c.baseURL, strings.Join(pageParams, "&"))
+
+		pageStart := c.clock.Now()
+		result := c.fetchWithRetry(ctx, cfg, fmt.Sprintf("cards-page-%d", page), url)
+		pagesFetched++
+
+		if !result.success {
+			return cards, page, fmt.Errorf("failed to fetch page %d: %w", page, result.err)
+		}
+
+		var cardsResp GetCardsResponse
+		if err := json.Unmarshal(result.data, &cardsResp); err != nil {
+			return cards, page, fmt.Errorf("failed to parse cards data for page %d: %w (raw: %s)", page, err, string(result.data))
+		}
+
+		pageCards := cardsResp.Data.Data
+		if predicate != nil {
+			filtered := make([]CardSummary, 0, len(pageCards))
+			for _, card := range pageCards {
+				if predicate(cardSummaryRecord(card)) {
+					filtered = append(filtered, card)
+				}
+			}
+			pageCards = filtered
+		}
+
+		cards = append(cards, pageCards...)
+		cumulative += len(pageCards)
+
+		nextDelayMs := int64(0)
+		if result.attempts > 1 {
+			nextDelayMs = c.clock.Now().Sub(pageStart).Milliseconds()
+		}
+
+		if emit != nil {
+			emit(CardStreamProgress{
+				Page:        page,
+				PerPage:     pageSize,
+				Returned:    len(pageCards),
+				Cumulative:  cumulative,
+				Attempt:     result.attempts,
+				NextDelayMs: nextDelayMs,
+			})
+		}
+
+		pagination := cardsResp.Data.Pagination
+		page++
+		if len(cardsResp.Data.Data) < pageSize || (pagination.AllPages > 0 && page > pagination.AllPages) {
+			return cards, 0, nil
+		}
+	}
+}
+
+// cardSummaryRecord flattens a CardSummary into the record shape the query
+// package's compiled Predicate expects.
+func cardSummaryRecord(card CardSummary) map[string]interface{} {
+	return map[string]interface{}{
+		"card_id":      card.CardID,
+		"card_ids":     card.CardID,
+		"title":        card.Title,
+		"description":  card.Description,
+		"board_ids":    card.BoardID,
+		"lane_ids":     card.LaneID,
+		"workflow_ids": card.WorkflowID,
+	}
 }
 
-func (c *Client) makeAPIRequestWithBody(method, url string, body interface{}) ([]byte, error) {
+// doRequest performs a single HTTP attempt and returns the *http.Response
+// alongside the body so retry logic can classify transient failures (status
+// code, headers) even when the status check fails. The response body is
+// always drained and closed before returning. Every outbound call funnels
+// through here, directly or via the retry transport in retry.go, so ctx
+// deadlines and cancellation apply uniformly. attempt (0-indexed) is recorded
+// as a span attribute and doesn't otherwise affect behavior.
+func (c *Client) doRequest(ctx context.Context, method, url string, body interface{}, attempt int) (data []byte, resp *http.Response, err error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, nil, fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+
+	start := time.Now()
+	ctx, endSpan := c.startRequestSpan(ctx, method, url, attempt)
+	defer func() {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		c.metrics.observeRequest(method, normalizeEndpoint(url), statusCode, time.Since(start).Seconds())
+		endSpan(statusCode, err)
+	}()
+
 	var requestBody io.Reader
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		jsonBody, marshalErr := json.Marshal(body)
+		if marshalErr != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", marshalErr)
 		}
 		requestBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, url, requestBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	req, reqErr := http.NewRequestWithContext(ctx, method, url, requestBody)
+	if reqErr != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", reqErr)
 	}
 
-	req.Header.Set("apikey", c.apiKey)
+	req.Header.Set("apikey", c.apiKeyFor(ctx))
 	req.Header.Set("Content-Type", "application/json")
+	if key := idempotencyKeyFor(ctx); key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	for key, values := range extraHeadersFor(ctx) {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+	httpResp, doErr := c.httpClientFor(ctx).Do(req)
+	if doErr != nil {
+		return nil, nil, fmt.Errorf("failed to execute request: %w", doErr)
 	}
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
 
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	responseBody, readErr := io.ReadAll(httpResp.Body)
+	if readErr != nil {
+		return nil, httpResp, fmt.Errorf("failed to read response body: %w", readErr)
 	}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
 		// Check for rate limiting first
-		if resp.StatusCode == http.StatusTooManyRequests {
-			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
-			return nil, &RateLimitError{
-				StatusCode: resp.StatusCode,
+		if httpResp.StatusCode == http.StatusTooManyRequests {
+			retryAfter := parseRetryAfter(httpResp.Header.Get("Retry-After"))
+			return nil, httpResp, &RateLimitError{
+				StatusCode: httpResp.StatusCode,
 				RetryAfter: retryAfter,
 				RawBody:    string(responseBody),
 			}
 		}
 
-		// Handle other API errors
+		// Handle other API errors. A body that parses into BusinessMap's
+		// {"error": "...", "error_code": "..."} shape becomes a typed
+		// *APIError so classifyRetryable can short-circuit on a permanent
+		// failure (auth, validation, not-found, permission) instead of
+		// retrying; anything else falls back to the generic HTTPStatusError.
 		var apiErr APIError
-		if err := json.Unmarshal(responseBody, &apiErr); err == nil {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, apiErr.Message)
+		if err := json.Unmarshal(responseBody, &apiErr); err == nil && (apiErr.Message != "" || apiErr.Code != "") {
+			apiErr.HTTPStatus = httpResp.StatusCode
+			return nil, httpResp, &apiErr
 		}
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
+		return nil, httpResp, &HTTPStatusError{StatusCode: httpResp.StatusCode, RawBody: string(responseBody)}
 	}
 
-	return responseBody, nil
+	return responseBody, httpResp, nil
 }
@@ -19,6 +19,7 @@ package kanbanize
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -807,6 +808,155 @@ func TestGetCardsWithRetry_RateLimitThenSuccess(t *testing.T) {
 	}
 }
 
+func TestGetCardsWithRetry_QueryDSLPredicateFiltersUnfilteredResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/cards" {
+			query := r.URL.Query()
+			if boardIDs := query.Get("board_ids"); boardIDs != "1,2" {
+				t.Errorf("expected pushed-down board_ids=1,2, got %s", boardIDs)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"data": {
+					"pagination": {"all_pages": 1, "current_page": 1, "results_per_page": 200},
+					"data": [
+						{"card_id": 101, "title": "Card 1", "board_id": 1, "lane_id": 10, "workflow_id": 100},
+						{"card_id": 102, "title": "Card 2", "board_id": 2, "lane_id": 20, "workflow_id": 200}
+					]
+				}
+			}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	filter := GetCardsRequest{Query: `board_ids IN (1,2) AND lane_ids = 10`}
+	cfg := DefaultRetryConfig()
+
+	response, err := client.GetCardsWithRetry(context.Background(), filter, cfg, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(response.Cards) != 1 || response.Cards[0].CardID != 101 {
+		t.Fatalf("expected only card 101 to survive the lane_ids predicate, got %+v", response.Cards)
+	}
+}
+
+func TestGetCardsWithRetry_InvalidQuery(t *testing.T) {
+	client := NewClient("http://example.com", "test-api-key")
+	filter := GetCardsRequest{Query: `board_ids IN (1,`}
+	cfg := DefaultRetryConfig()
+
+	if _, err := client.GetCardsWithRetry(context.Background(), filter, cfg, false); err == nil {
+		t.Fatal("expected error for invalid query syntax")
+	}
+}
+
+func TestGetCardsStream_PagesUntilShortPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/cards" {
+			return
+		}
+		page := r.URL.Query().Get("page")
+		w.WriteHeader(http.StatusOK)
+		switch page {
+		case "1":
+			w.Write([]byte(`{"data": {"pagination": {"all_pages": 2, "current_page": 1, "results_per_page": 2}, "data": [
+				{"card_id": 101, "title": "Card 1", "board_id": 1, "lane_id": 10, "workflow_id": 100},
+				{"card_id": 102, "title": "Card 2", "board_id": 1, "lane_id": 10, "workflow_id": 100}
+			]}}`))
+		case "2":
+			w.Write([]byte(`{"data": {"pagination": {"all_pages": 2, "current_page": 2, "results_per_page": 2}, "data": [
+				{"card_id": 103, "title": "Card 3", "board_id": 1, "lane_id": 10, "workflow_id": 100}
+			]}}`))
+		default:
+			t.Errorf("unexpected page %q", page)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+	cfg := DefaultRetryConfig()
+
+	var progress []CardStreamProgress
+	cards, nextPage, err := client.GetCardsStream(context.Background(), filter, cfg,
+		CardStreamOptions{PageSize: 2}, func(p CardStreamProgress) {
+			progress = append(progress, p)
+		})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(cards) != 3 {
+		t.Fatalf("expected 3 cards across both pages, got %d", len(cards))
+	}
+	if nextPage != 0 {
+		t.Errorf("expected nextPage=0 once the short page ends the stream, got %d", nextPage)
+	}
+	if len(progress) != 2 {
+		t.Fatalf("expected one progress callback per page, got %d", len(progress))
+	}
+	if progress[0].Page != 1 || progress[0].Returned != 2 || progress[0].Cumulative != 2 {
+		t.Errorf("unexpected first page progress: %+v", progress[0])
+	}
+	if progress[1].Page != 2 || progress[1].Returned != 1 || progress[1].Cumulative != 3 {
+		t.Errorf("unexpected second page progress: %+v", progress[1])
+	}
+}
+
+func TestGetCardsStream_MaxPagesStopsEarlyWithResumableNextPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/cards" {
+			return
+		}
+		page := r.URL.Query().Get("page")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"data": {"pagination": {"all_pages": 5, "current_page": %s, "results_per_page": 1}, "data": [
+			{"card_id": 1, "title": "Card", "board_id": 1, "lane_id": 10, "workflow_id": 100}
+		]}}`, page)))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+	cfg := DefaultRetryConfig()
+
+	cards, nextPage, err := client.GetCardsStream(context.Background(), filter, cfg,
+		CardStreamOptions{PageSize: 1, MaxPages: 2}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("expected MaxPages to stop after 2 pages, got %d cards", len(cards))
+	}
+	if nextPage != 3 {
+		t.Errorf("expected nextPage=3 so a caller can resume, got %d", nextPage)
+	}
+
+	cards, nextPage, err = client.GetCardsStream(context.Background(), filter, cfg,
+		CardStreamOptions{PageSize: 1, StartPage: nextPage, MaxPages: 1}, nil)
+	if err != nil {
+		t.Fatalf("expected no error resuming, got %v", err)
+	}
+	if len(cards) != 1 || cards[0].CardID != 1 {
+		t.Fatalf("expected the resumed page's card, got %+v", cards)
+	}
+	if nextPage != 4 {
+		t.Errorf("expected nextPage=4 after resuming from page 3, got %d", nextPage)
+	}
+}
+
+func TestGetCardsStream_NoFilterProvided(t *testing.T) {
+	client := NewClient("http://example.com", "test-api-key")
+	cfg := DefaultRetryConfig()
+
+	if _, _, err := client.GetCardsStream(context.Background(), GetCardsRequest{}, cfg, CardStreamOptions{}, nil); err == nil {
+		t.Fatal("expected error when no filter is provided")
+	}
+}
+
 // Helper function for string contains
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
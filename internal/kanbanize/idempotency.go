@@ -0,0 +1,147 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// Defaults for a Client's idempotency cache, used unless overridden via
+// WithIdempotencyCache.
+const (
+	defaultIdempotencyCapacity = 1000
+	defaultIdempotencyTTL      = 10 * time.Minute
+)
+
+// idempotencyEntry is the cached outcome of a prior mutating call made under
+// a given idempotency key.
+type idempotencyEntry struct {
+	statusCode  int
+	body        []byte
+	contentHash string
+	expiresAt   time.Time
+}
+
+// idempotencyCache is a small in-memory LRU of idempotency key ->
+// idempotencyEntry, bounded by capacity and TTL so a long-lived Client
+// doesn't accumulate this state forever. It lets AddCardCommentContext (and
+// any future mutating call that accepts option.WithIdempotencyKey or
+// option.WithAutoIdempotency) replay a prior successful response instead of
+// re-sending a mutation whose result the caller already has, e.g. after a
+// network failure masked whether the original request actually landed.
+type idempotencyCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*idempotencyEntry
+	order    []string // least-recently-used first
+}
+
+func newIdempotencyCache(capacity int, ttl time.Duration) *idempotencyCache {
+	return &idempotencyCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*idempotencyEntry),
+	}
+}
+
+// contentMismatch reports whether requestContent hashes to something other
+// than e's cached contentHash, i.e. key is being reused for a logically
+// different request than the one e's cached response actually answered.
+func (e *idempotencyEntry) contentMismatch(requestContent []byte) bool {
+	hash := sha256.Sum256(requestContent)
+	return hex.EncodeToString(hash[:]) != e.contentHash
+}
+
+// get returns the cached entry for key, if any and not yet past its TTL.
+func (c *idempotencyCache) get(key string) (*idempotencyEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		c.order = removeIdempotencyKey(c.order, key)
+		return nil, false
+	}
+
+	c.touch(key)
+	return entry, true
+}
+
+// put records a successful response under key, evicting the
+// least-recently-used entry if the cache is already at capacity.
+// contentHash is computed from requestContent (the request's own payload,
+// not its response) so a later call reusing key can tell whether it's
+// replaying the same logical request or silently dropping a different one;
+// see contentMismatch.
+func (c *idempotencyCache) put(key string, statusCode int, body, requestContent []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hash := sha256.Sum256(requestContent)
+	c.entries[key] = &idempotencyEntry{
+		statusCode:  statusCode,
+		body:        body,
+		contentHash: hex.EncodeToString(hash[:]),
+		expiresAt:   time.Now().Add(c.ttl),
+	}
+	c.touch(key)
+
+	for len(c.order) > c.capacity {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.entries, oldest)
+	}
+}
+
+// touch moves key to the most-recently-used end of order. Callers must hold c.mu.
+func (c *idempotencyCache) touch(key string) {
+	c.order = removeIdempotencyKey(c.order, key)
+	c.order = append(c.order, key)
+}
+
+func removeIdempotencyKey(order []string, key string) []string {
+	for i, k := range order {
+		if k == key {
+			return append(order[:i], order[i+1:]...)
+		}
+	}
+	return order
+}
+
+// newIdempotencyKey generates a random UUIDv4 for option.WithAutoIdempotency,
+// since this repo has no UUID dependency to reuse. It returns "" on the
+// practically-never-happens case that the system CSPRNG is unavailable, in
+// which case auto idempotency silently has no effect for that call.
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return hex.EncodeToString(b[0:4]) + "-" + hex.EncodeToString(b[4:6]) + "-" +
+		hex.EncodeToString(b[6:8]) + "-" + hex.EncodeToString(b[8:10]) + "-" + hex.EncodeToString(b[10:16])
+}
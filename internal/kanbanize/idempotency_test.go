@@ -0,0 +1,149 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hivemq/businessmap-mcp/internal/kanbanize/option"
+)
+
+func TestAddCardCommentContext_IdempotencyReplayAfterSuccess(t *testing.T) {
+	var requestCount int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AddCommentResponse{})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+
+	if err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithIdempotencyKey("fixed-key")); err != nil {
+		t.Fatalf("Expected no error on first call, got %v", err)
+	}
+	if err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithIdempotencyKey("fixed-key")); err != nil {
+		t.Fatalf("Expected no error on replayed call, got %v", err)
+	}
+
+	if requestCount != 1 {
+		t.Errorf("Expected the second call to replay from cache without hitting the server, got %d requests", requestCount)
+	}
+}
+
+func TestAddCardCommentContext_IdempotencyReplayOnNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AddCommentResponse{})
+	}))
+
+	client := NewClient(server.URL, "test-api-key")
+	if err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithIdempotencyKey("key-before-outage")); err != nil {
+		t.Fatalf("Expected no error on first call, got %v", err)
+	}
+
+	// Simulate the server becoming unreachable after the key's first success
+	// was cached; the replay must not attempt a new request at all.
+	server.Close()
+
+	if err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithIdempotencyKey("key-before-outage")); err != nil {
+		t.Errorf("Expected the cached response to be replayed without contacting the now-unreachable server, got %v", err)
+	}
+}
+
+func TestAddCardCommentContext_IdempotencyRejectsKeyReuseWithDifferentContent(t *testing.T) {
+	var requestCount int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AddCommentResponse{})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+
+	if err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithIdempotencyKey("reused-key")); err != nil {
+		t.Fatalf("Expected no error on first call, got %v", err)
+	}
+
+	err := client.AddCardCommentContext(context.Background(), "1001", "goodbye", option.WithIdempotencyKey("reused-key"))
+	if err == nil {
+		t.Fatal("Expected an error reusing the key for a different comment, got nil")
+	}
+
+	if requestCount != 1 {
+		t.Errorf("Expected the mismatched reuse to be rejected without sending \"goodbye\" to the server, got %d requests", requestCount)
+	}
+}
+
+func TestAddCardCommentContext_IdempotencyTTLExpiry(t *testing.T) {
+	var requestCount int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AddCommentResponse{})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithIdempotencyCache(defaultIdempotencyCapacity, 10*time.Millisecond))
+
+	if err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithIdempotencyKey("expiring-key")); err != nil {
+		t.Fatalf("Expected no error on first call, got %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithIdempotencyKey("expiring-key")); err != nil {
+		t.Fatalf("Expected no error on second call, got %v", err)
+	}
+
+	if requestCount != 2 {
+		t.Errorf("Expected the cache entry to have expired, causing a second real request, got %d requests", requestCount)
+	}
+}
+
+func TestAddCardCommentContext_AutoIdempotencyGeneratesDistinctKeys(t *testing.T) {
+	var gotKeys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKeys = append(gotKeys, r.Header.Get("Idempotency-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AddCommentResponse{})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+
+	if err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithAutoIdempotency()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.AddCardCommentContext(context.Background(), "1001", "world", option.WithAutoIdempotency()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(gotKeys) != 2 || gotKeys[0] == "" || gotKeys[1] == "" || gotKeys[0] == gotKeys[1] {
+		t.Errorf("Expected two distinct, non-empty auto-generated idempotency keys, got %v", gotKeys)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// clientMetrics holds the Prometheus collectors registered via
+// WithMetrics. All fields are nil-safe through the Client.metrics
+// nil check, so instrumentation is a no-op unless WithMetrics is used.
+type clientMetrics struct {
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	rateLimitHits   *prometheus.CounterVec
+	retryAttempts   *prometheus.CounterVec
+	circuitState    *prometheus.GaugeVec
+}
+
+func newClientMetrics(reg prometheus.Registerer) *clientMetrics {
+	m := &clientMetrics{
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kanbanize_requests_total",
+			Help: "Total outbound Kanbanize API requests by method, endpoint, and status.",
+		}, []string{"method", "endpoint", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "kanbanize_request_duration_seconds",
+			Help:    "Kanbanize API request latency by endpoint.",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"endpoint"}),
+		rateLimitHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kanbanize_rate_limit_hits_total",
+			Help: "Total HTTP 429 responses received from the Kanbanize API by endpoint.",
+		}, []string{"endpoint"}),
+		retryAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kanbanize_retry_attempts",
+			Help: "Total retry attempts made against the Kanbanize API by endpoint.",
+		}, []string{"endpoint"}),
+		circuitState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "kanbanize_circuit_state",
+			Help: "Per-host circuit breaker state (0=closed, 1=open, 2=half-open).",
+		}, []string{"host"}),
+	}
+
+	reg.MustRegister(m.requestsTotal, m.requestDuration, m.rateLimitHits, m.retryAttempts, m.circuitState)
+	return m
+}
+
+// endpointIDPattern matches numeric path segments so endpoint labels stay
+// low-cardinality, e.g. "/api/v2/cards/1001/comments" -> "/api/v2/cards/{id}/comments".
+var endpointIDPattern = regexp.MustCompile(`/\d+`)
+
+// normalizeEndpoint strips the scheme/host and any numeric IDs from a
+// request URL, producing a label value suitable for Prometheus metrics.
+func normalizeEndpoint(rawURL string) string {
+	path := rawURL
+	if idx := strings.Index(rawURL, "://"); idx >= 0 {
+		rest := rawURL[idx+3:]
+		if slash := strings.Index(rest, "/"); slash >= 0 {
+			path = rest[slash:]
+		} else {
+			path = "/"
+		}
+	}
+	if q := strings.IndexByte(path, '?'); q >= 0 {
+		path = path[:q]
+	}
+	return endpointIDPattern.ReplaceAllString(path, "/{id}")
+}
+
+func (m *clientMetrics) observeRequest(method, endpoint string, statusCode int, duration float64) {
+	if m == nil {
+		return
+	}
+	status := strconv.Itoa(statusCode)
+	m.requestsTotal.WithLabelValues(method, endpoint, status).Inc()
+	m.requestDuration.WithLabelValues(endpoint).Observe(duration)
+	if statusCode == http.StatusTooManyRequests {
+		m.rateLimitHits.WithLabelValues(endpoint).Inc()
+	}
+}
+
+func (m *clientMetrics) observeRetryAttempt(endpoint string) {
+	if m == nil {
+		return
+	}
+	m.retryAttempts.WithLabelValues(endpoint).Inc()
+}
+
+func (m *clientMetrics) observeCircuitState(host string, state circuitState) {
+	if m == nil {
+		return
+	}
+	m.circuitState.WithLabelValues(host).Set(float64(state))
+}
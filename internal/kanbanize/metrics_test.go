@@ -0,0 +1,99 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestNormalizeEndpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"card", "https://example.businessmap.io/api/v2/cards/1001", "/api/v2/cards/{id}"},
+		{"comments", "https://example.businessmap.io/api/v2/cards/1001/comments", "/api/v2/cards/{id}/comments"},
+		{"query string stripped", "https://example.businessmap.io/api/v2/cards?board_ids=1,2", "/api/v2/cards"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeEndpoint(tt.url); got != tt.want {
+				t.Errorf("normalizeEndpoint(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithMetrics_RecordsRequestsAndRetries(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":{"title":"ok"}}`))
+	}))
+	defer server.Close()
+
+	reg := prometheus.NewRegistry()
+	client := NewClient(server.URL, "test-api-key", WithMetrics(reg))
+
+	if _, err := client.ReadCard("1001"); err != nil {
+		t.Fatalf("ReadCard returned error: %v", err)
+	}
+
+	metricFamilies, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	var sawRequests, sawRetries bool
+	for _, mf := range metricFamilies {
+		switch mf.GetName() {
+		case "kanbanize_requests_total":
+			sawRequests = len(mf.GetMetric()) > 0
+		case "kanbanize_retry_attempts":
+			sawRetries = sumCounters(mf.GetMetric()) > 0
+		}
+	}
+
+	if !sawRequests {
+		t.Error("expected kanbanize_requests_total to have samples")
+	}
+	if !sawRetries {
+		t.Error("expected kanbanize_retry_attempts to record the retried request")
+	}
+}
+
+func sumCounters(metrics []*dto.Metric) float64 {
+	var total float64
+	for _, m := range metrics {
+		if c := m.GetCounter(); c != nil {
+			total += c.GetValue()
+		}
+	}
+	return total
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RetryEvent describes one HTTP attempt made by makeRequestWithRetry,
+// fetchWithRetry, or fetchWithRetryShared, passed to RetryObserver.OnAttempt
+// once the attempt completes.
+type RetryEvent struct {
+	// Name labels the endpoint for fan-out callers (e.g. "comments",
+	// "subtasks"); empty for single-endpoint callers like makeRequestWithRetry.
+	Name        string
+	URL         string
+	Attempt     int
+	MaxAttempts int
+	StatusCode  int
+	Duration    time.Duration
+	Err         error
+
+	// RateLimitCategory is set when Err is a rate-limit error, naming the
+	// RateLimitMap category (see categoryForURL) that was hit.
+	RateLimitCategory string
+}
+
+// RetryObserver receives retry-lifecycle notifications in place of the retry
+// loops logging directly. Set RetryConfig.Observer to customize; the zero
+// value falls back to LogObserver, which reproduces the historical
+// "[RETRY] ..." stderr lines.
+type RetryObserver interface {
+	// OnAttempt is called once per HTTP attempt, after it completes.
+	OnAttempt(ctx context.Context, event RetryEvent)
+	// OnBackoff is called before a loop sleeps delay before its next
+	// attempt; reason is a short human-readable description of why the
+	// previous attempt is being retried.
+	OnBackoff(ctx context.Context, delay time.Duration, reason string)
+	// OnGiveUp is called once, when a loop stops retrying without
+	// succeeding.
+	OnGiveUp(ctx context.Context, err error)
+	// OnSuccess is called once, when a loop's attempt succeeds after at
+	// least one retry; a success on the first attempt does not call it.
+	OnSuccess(ctx context.Context, attempts int, totalWait time.Duration)
+}
+
+// observerFor returns cfg.Observer, or LogObserver{} if the caller left it
+// unset.
+func (cfg RetryConfig) observerFor() RetryObserver {
+	if cfg.Observer != nil {
+		return cfg.Observer
+	}
+	return LogObserver{}
+}
+
+// LogObserver is the default RetryObserver: it reproduces the retry loops'
+// historical "[RETRY] ..." lines via the standard log package.
+type LogObserver struct{}
+
+func (LogObserver) OnAttempt(ctx context.Context, event RetryEvent) {
+	if event.Err == nil {
+		return
+	}
+	if event.Name != "" {
+		log.Printf("[RETRY] %s attempt %d/%d failed (%v)", event.Name, event.Attempt, event.MaxAttempts, event.Err)
+		return
+	}
+	log.Printf("[RETRY] Attempt %d/%d failed for %s (%v)", event.Attempt, event.MaxAttempts, event.URL, event.Err)
+}
+
+func (LogObserver) OnBackoff(ctx context.Context, delay time.Duration, reason string) {
+	log.Printf("[RETRY] %s, waiting %v", reason, delay)
+}
+
+func (LogObserver) OnGiveUp(ctx context.Context, err error) {
+	log.Printf("[RETRY] giving up: %v", err)
+}
+
+func (LogObserver) OnSuccess(ctx context.Context, attempts int, totalWait time.Duration) {
+	if attempts > 1 {
+		log.Printf("[RETRY] Success after %d attempts, total wait: %v", attempts, totalWait)
+	}
+}
+
+// OTelObserver is a RetryObserver that records retry activity as OpenTelemetry
+// spans and span events instead of log lines: one span per HTTP attempt
+// (with http.retry_count, http.status_code, and, on a rate-limit error,
+// rate_limit.category), plus backoff/give-up/success events on whatever span
+// is already active in ctx. Combined with Client.startCallSpan (used by
+// ReadCardWithRetry), the per-attempt spans and events nest under one parent
+// span for the whole retry call, so a trace backend like Jaeger or Tempo
+// shows exactly how much wall time went to rate-limit backoff versus real
+// request time.
+type OTelObserver struct {
+	tracer trace.Tracer
+}
+
+// NewOTelObserver builds an OTelObserver that starts spans on tracer.
+func NewOTelObserver(tracer trace.Tracer) *OTelObserver {
+	return &OTelObserver{tracer: tracer}
+}
+
+func (o *OTelObserver) OnAttempt(ctx context.Context, event RetryEvent) {
+	if o.tracer == nil {
+		return
+	}
+
+	end := time.Now()
+	start := end.Add(-event.Duration)
+	attrs := []attribute.KeyValue{
+		attribute.String("http.url", event.URL),
+		attribute.Int("http.retry_count", event.Attempt),
+	}
+	if event.RateLimitCategory != "" {
+		attrs = append(attrs, attribute.String("rate_limit.category", event.RateLimitCategory))
+	}
+
+	_, span := o.tracer.Start(ctx, "kanbanize.retry_attempt", trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	if event.StatusCode > 0 {
+		span.SetAttributes(attribute.Int("http.status_code", event.StatusCode))
+	}
+	if event.Err != nil {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+func (o *OTelObserver) OnBackoff(ctx context.Context, delay time.Duration, reason string) {
+	trace.SpanFromContext(ctx).AddEvent("kanbanize.retry_backoff", trace.WithAttributes(
+		attribute.String("reason", reason),
+		attribute.Int64("http.retry_after_ms", delay.Milliseconds()),
+	))
+}
+
+func (o *OTelObserver) OnGiveUp(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func (o *OTelObserver) OnSuccess(ctx context.Context, attempts int, totalWait time.Duration) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int("http.retry_count", attempts),
+		attribute.Int64("http.retry_after_ms", totalWait.Milliseconds()),
+	)
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeObserver is a RetryObserver that records every call it receives, for
+// assertions in tests.
+type fakeObserver struct {
+	mu        sync.Mutex
+	attempts  []RetryEvent
+	backoffs  int
+	gaveUp    []error
+	successes int
+}
+
+func (o *fakeObserver) OnAttempt(ctx context.Context, event RetryEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.attempts = append(o.attempts, event)
+}
+
+func (o *fakeObserver) OnBackoff(ctx context.Context, delay time.Duration, reason string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.backoffs++
+}
+
+func (o *fakeObserver) OnGiveUp(ctx context.Context, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.gaveUp = append(o.gaveUp, err)
+}
+
+func (o *fakeObserver) OnSuccess(ctx context.Context, attempts int, totalWait time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.successes++
+}
+
+func TestLogObserver_DoesNotPanic(t *testing.T) {
+	var observer LogObserver
+	ctx := context.Background()
+	observer.OnAttempt(ctx, RetryEvent{URL: "http://example.com", Attempt: 1, MaxAttempts: 3})
+	observer.OnAttempt(ctx, RetryEvent{URL: "http://example.com", Attempt: 2, MaxAttempts: 3, Err: context.DeadlineExceeded})
+	observer.OnBackoff(ctx, 10*time.Millisecond, "attempt 1/3 failed")
+	observer.OnGiveUp(ctx, context.DeadlineExceeded)
+	observer.OnSuccess(ctx, 2, 10*time.Millisecond)
+}
+
+func TestOTelObserver_NilTracerIsNoop(t *testing.T) {
+	observer := NewOTelObserver(nil)
+	ctx := context.Background()
+	observer.OnAttempt(ctx, RetryEvent{URL: "http://example.com", Attempt: 1, MaxAttempts: 1})
+}
+
+func TestOTelObserver_RecordsAttemptSpan(t *testing.T) {
+	tracer := trace.NewNoopTracerProvider().Tracer("test")
+	observer := NewOTelObserver(tracer)
+	ctx := context.Background()
+
+	observer.OnAttempt(ctx, RetryEvent{URL: "http://example.com", Attempt: 1, MaxAttempts: 3, StatusCode: 429, RateLimitCategory: "cards"})
+	observer.OnBackoff(ctx, 10*time.Millisecond, "attempt 1/3 failed")
+	observer.OnGiveUp(ctx, context.DeadlineExceeded)
+	observer.OnSuccess(ctx, 2, 10*time.Millisecond)
+}
+
+func TestMakeRequestWithRetry_ObserverReceivesAttemptsAndSuccess(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"ok": "true"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	observer := &fakeObserver{}
+	cfg := RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 5 * time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		Multiplier:   2.0,
+		JitterMode:   JitterNone,
+		TotalWaitCap: time.Second,
+		Observer:     observer,
+	}
+
+	if _, err := client.makeRequestWithRetry(context.Background(), cfg, "GET", server.URL, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(observer.attempts) != 2 {
+		t.Fatalf("expected 2 recorded attempts, got %d", len(observer.attempts))
+	}
+	if observer.attempts[0].Err == nil {
+		t.Error("expected the first attempt to record its failure")
+	}
+	if observer.attempts[1].Err != nil {
+		t.Error("expected the second attempt to record success")
+	}
+	if observer.successes != 1 {
+		t.Errorf("expected OnSuccess to fire once, got %d", observer.successes)
+	}
+	if observer.backoffs != 1 {
+		t.Errorf("expected OnBackoff to fire once between the two attempts, got %d", observer.backoffs)
+	}
+}
+
+func TestFetchWithRetry_ObserverReceivesGiveUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	observer := &fakeObserver{}
+	cfg := RetryConfig{
+		MaxAttempts:  2,
+		InitialDelay: 5 * time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		Multiplier:   2.0,
+		JitterMode:   JitterNone,
+		TotalWaitCap: time.Second,
+		Observer:     observer,
+	}
+
+	result := client.fetchWithRetry(context.Background(), cfg, "comments", server.URL)
+
+	if result.success {
+		t.Fatal("expected the fetch to keep failing against a 503-only server")
+	}
+	if len(observer.gaveUp) != 1 {
+		t.Fatalf("expected OnGiveUp to fire once, got %d", len(observer.gaveUp))
+	}
+	if len(observer.attempts) != cfg.MaxAttempts {
+		t.Errorf("expected %d recorded attempts, got %d", cfg.MaxAttempts, len(observer.attempts))
+	}
+}
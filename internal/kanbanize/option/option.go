@@ -0,0 +1,111 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package option provides functional options for overriding a kanbanize
+// Client's behavior on a single call, without changing that call's method
+// signature for every new knob. It is a separate package from kanbanize
+// itself so its constructors (WithHTTPClient, WithBaseURL, ...) can reuse
+// names already taken by kanbanize's Client-construction Option type (see
+// client.go) without colliding with them.
+package option
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Config holds the resolved per-call overrides a RequestOption can set. A
+// Client method starts from its own construction-time defaults and then
+// applies every RequestOption passed to that call, in order, so later
+// options win over earlier ones and all of them win over the client's
+// defaults.
+type Config struct {
+	APIKey          string
+	HTTPClient      *http.Client
+	BaseURL         string
+	Headers         http.Header
+	MaxAttempts     int
+	InitialDelay    time.Duration
+	Context         context.Context
+	IdempotencyKey  string
+	AutoIdempotency bool
+}
+
+// RequestOption configures a single Client call.
+type RequestOption func(*Config)
+
+// WithAPIKey overrides the API key used for this call only.
+func WithAPIKey(apiKey string) RequestOption {
+	return func(c *Config) { c.APIKey = apiKey }
+}
+
+// WithHTTPClient overrides the http.Client used for this call only.
+func WithHTTPClient(httpClient *http.Client) RequestOption {
+	return func(c *Config) { c.HTTPClient = httpClient }
+}
+
+// WithBaseURL overrides the BusinessMap base URL used for this call only.
+func WithBaseURL(baseURL string) RequestOption {
+	return func(c *Config) { c.BaseURL = baseURL }
+}
+
+// WithHTTPHeader adds a header to the outbound request(s) for this call
+// only. Calling it more than once with the same key appends additional
+// values, matching http.Header.Add.
+func WithHTTPHeader(key, value string) RequestOption {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Add(key, value)
+	}
+}
+
+// WithMaxAttempts overrides RetryConfig.MaxAttempts for this call only.
+func WithMaxAttempts(maxAttempts int) RequestOption {
+	return func(c *Config) { c.MaxAttempts = maxAttempts }
+}
+
+// WithInitialDelay overrides RetryConfig.InitialDelay for this call only.
+func WithInitialDelay(delay time.Duration) RequestOption {
+	return func(c *Config) { c.InitialDelay = delay }
+}
+
+// WithContext supplies a context to a method variant that doesn't already
+// take one (e.g. ReadCard instead of ReadCardContext), so a caller that
+// can't change which method it calls can still bound the call with a
+// deadline or cancellation.
+func WithContext(ctx context.Context) RequestOption {
+	return func(c *Config) { c.Context = ctx }
+}
+
+// WithIdempotencyKey sets an explicit idempotency key for this call. A
+// mutating call (e.g. AddCardCommentContext) made under a key that has
+// already produced a successful response replays that response instead of
+// sending the request again, so retrying a call after a network failure
+// can't duplicate its effect on the BusinessMap side.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(c *Config) { c.IdempotencyKey = key }
+}
+
+// WithAutoIdempotency generates a random idempotency key for this call when
+// the caller hasn't supplied one via WithIdempotencyKey, so a single
+// user-initiated mutation can be retried across network failures without
+// risk of duplicating it server-side.
+func WithAutoIdempotency() RequestOption {
+	return func(c *Config) { c.AutoIdempotency = true }
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package query implements a small JQL-inspired filter language for
+// GetCardsWithRetry, e.g.
+//
+//	board_ids IN (1,2) AND (lane_ids = 7 OR workflow_ids IN (3,4)) AND assignee = "alice"
+//
+// Parse produces an AST of And/Or/Not/Cmp nodes; Compile turns that AST into
+// the subset of BusinessMap API v2 query parameters it can express natively
+// plus a Predicate that re-evaluates the full expression against a fetched
+// card, so filtering stays correct even for fields the API can't filter on.
+package query
+
+import "time"
+
+// Operator is a comparison operator usable in a Cmp node.
+type Operator string
+
+const (
+	OpEq       Operator = "="
+	OpNe       Operator = "!="
+	OpIn       Operator = "IN"
+	OpNotIn    Operator = "NOT IN"
+	OpGt       Operator = ">"
+	OpLt       Operator = "<"
+	OpGe       Operator = ">="
+	OpLe       Operator = "<="
+	OpContains Operator = "CONTAINS"
+	OpIsNull   Operator = "IS NULL"
+)
+
+// ValueKind identifies the literal kind carried by a Value.
+type ValueKind int
+
+const (
+	KindString ValueKind = iota
+	KindInt
+	KindDate
+	KindList
+)
+
+// Value is a literal on the right-hand side of a Cmp node. Exactly one of
+// Str/Int/Date is meaningful for scalar kinds; List holds one Value per
+// element for KindList (used by IN / NOT IN).
+type Value struct {
+	Kind ValueKind
+	Str  string
+	Int  int
+	Date time.Time
+	List []Value
+}
+
+// Node is any AST node: And, Or, Not, or Cmp.
+type Node interface {
+	node()
+}
+
+// And is the conjunction of two sub-expressions.
+type And struct {
+	Left  Node
+	Right Node
+}
+
+// Or is the disjunction of two sub-expressions.
+type Or struct {
+	Left  Node
+	Right Node
+}
+
+// Not negates a sub-expression.
+type Not struct {
+	Expr Node
+}
+
+// Cmp compares a field against a value, e.g. `assignee = "alice"`.
+// Value is the zero Value for OpIsNull, which takes no right-hand side.
+type Cmp struct {
+	Field string
+	Op    Operator
+	Value Value
+}
+
+func (And) node() {}
+func (Or) node()  {}
+func (Not) node() {}
+func (Cmp) node() {}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// nativeFields lists the record fields the BusinessMap v2 cards endpoint can
+// filter on natively via query parameters.
+var nativeFields = map[string]bool{
+	"board_ids":    true,
+	"lane_ids":     true,
+	"workflow_ids": true,
+	"card_ids":     true,
+}
+
+// Compiled is the result of compiling a query AST: the portion pushed down
+// into BusinessMap API query parameters, plus a Predicate that re-evaluates
+// the full expression against a fetched record (CardSummary or CardData
+// flattened into a map) so correctness doesn't depend on the pushdown being
+// complete.
+type Compiled struct {
+	BoardIDs    []int
+	LaneIDs     []int
+	WorkflowIDs []int
+	CardIDs     []int
+
+	Predicate func(record map[string]interface{}) bool
+}
+
+// Compile walks node once to extract any top-level (AND-only) comparisons
+// against nativeFields into API-pushdown filters, and builds a Predicate
+// that evaluates the complete expression against a record map. The pushdown
+// is an optimization only: Predicate is always correct on its own.
+func Compile(node Node) (*Compiled, error) {
+	c := &Compiled{}
+	collectPushdown(node, c)
+
+	pred, err := compilePredicate(node)
+	if err != nil {
+		return nil, err
+	}
+	c.Predicate = pred
+	return c, nil
+}
+
+// collectPushdown extracts IN/= comparisons on nativeFields from the
+// top-level conjunction chain. It does not descend into Or or Not, since
+// those can't be safely pushed down as an additive API filter.
+func collectPushdown(node Node, c *Compiled) {
+	and, ok := node.(And)
+	if ok {
+		collectPushdown(and.Left, c)
+		collectPushdown(and.Right, c)
+		return
+	}
+
+	cmp, ok := node.(Cmp)
+	if !ok || !nativeFields[cmp.Field] {
+		return
+	}
+
+	var ids []int
+	switch cmp.Op {
+	case OpEq:
+		if cmp.Value.Kind == KindInt {
+			ids = []int{cmp.Value.Int}
+		}
+	case OpIn:
+		if cmp.Value.Kind == KindList {
+			for _, v := range cmp.Value.List {
+				if v.Kind == KindInt {
+					ids = append(ids, v.Int)
+				}
+			}
+		}
+	default:
+		return
+	}
+
+	switch cmp.Field {
+	case "board_ids":
+		c.BoardIDs = append(c.BoardIDs, ids...)
+	case "lane_ids":
+		c.LaneIDs = append(c.LaneIDs, ids...)
+	case "workflow_ids":
+		c.WorkflowIDs = append(c.WorkflowIDs, ids...)
+	case "card_ids":
+		c.CardIDs = append(c.CardIDs, ids...)
+	}
+}
+
+func compilePredicate(node Node) (func(map[string]interface{}) bool, error) {
+	switch n := node.(type) {
+	case And:
+		left, err := compilePredicate(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compilePredicate(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(r map[string]interface{}) bool { return left(r) && right(r) }, nil
+	case Or:
+		left, err := compilePredicate(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compilePredicate(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(r map[string]interface{}) bool { return left(r) || right(r) }, nil
+	case Not:
+		inner, err := compilePredicate(n.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return func(r map[string]interface{}) bool { return !inner(r) }, nil
+	case Cmp:
+		return compileCmp(n)
+	default:
+		return nil, fmt.Errorf("query: unknown node type %T", node)
+	}
+}
+
+func compileCmp(cmp Cmp) (func(map[string]interface{}) bool, error) {
+	field := cmp.Field
+	op := cmp.Op
+	value := cmp.Value
+
+	return func(record map[string]interface{}) bool {
+		actual, present := record[field]
+
+		if op == OpIsNull {
+			return !present || actual == nil
+		}
+		if !present || actual == nil {
+			return false
+		}
+
+		switch op {
+		case OpEq:
+			return compareEqual(actual, value)
+		case OpNe:
+			return !compareEqual(actual, value)
+		case OpIn:
+			for _, v := range value.List {
+				if compareEqual(actual, v) {
+					return true
+				}
+			}
+			return false
+		case OpNotIn:
+			for _, v := range value.List {
+				if compareEqual(actual, v) {
+					return false
+				}
+			}
+			return true
+		case OpContains:
+			s, ok := actual.(string)
+			return ok && strings.Contains(s, value.Str)
+		case OpGt, OpLt, OpGe, OpLe:
+			return compareOrdered(actual, value, op)
+		default:
+			return false
+		}
+	}, nil
+}
+
+func compareEqual(actual interface{}, value Value) bool {
+	switch value.Kind {
+	case KindInt:
+		n, ok := actual.(int)
+		return ok && n == value.Int
+	case KindString:
+		s, ok := actual.(string)
+		return ok && s == value.Str
+	case KindDate:
+		t, ok := actual.(time.Time)
+		return ok && t.Equal(value.Date)
+	default:
+		return false
+	}
+}
+
+func compareOrdered(actual interface{}, value Value, op Operator) bool {
+	var cmp int
+	switch value.Kind {
+	case KindInt:
+		n, ok := actual.(int)
+		if !ok {
+			return false
+		}
+		cmp = n - value.Int
+	case KindDate:
+		t, ok := actual.(time.Time)
+		if !ok {
+			return false
+		}
+		switch {
+		case t.Before(value.Date):
+			cmp = -1
+		case t.After(value.Date):
+			cmp = 1
+		default:
+			cmp = 0
+		}
+	case KindString:
+		s, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(s, value.Str)
+	default:
+		return false
+	}
+
+	switch op {
+	case OpGt:
+		return cmp > 0
+	case OpLt:
+		return cmp < 0
+	case OpGe:
+		return cmp >= 0
+	case OpLe:
+		return cmp <= 0
+	default:
+		return false
+	}
+}
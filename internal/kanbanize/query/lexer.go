@@ -0,0 +1,168 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokInt
+	tokLParen
+	tokRParen
+	tokComma
+	tokAnd
+	tokOr
+	tokNot
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lexer tokenizes a query string. Keywords (AND, OR, NOT, IN, CONTAINS, IS,
+// NULL) are matched case-insensitively; everything else is an identifier,
+// quoted string, integer, operator, or parenthesis.
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: []rune(input)}
+}
+
+func (l *lexer) peekRune() (rune, bool) {
+	if l.pos >= len(l.input) {
+		return 0, false
+	}
+	return l.input[l.pos], true
+}
+
+func (l *lexer) skipSpace() {
+	for {
+		r, ok := l.peekRune()
+		if !ok || !unicode.IsSpace(r) {
+			return
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpace()
+	r, ok := l.peekRune()
+	if !ok {
+		return token{kind: tokEOF}, nil
+	}
+
+	switch {
+	case r == '(':
+		l.pos++
+		return token{kind: tokLParen, text: "("}, nil
+	case r == ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")"}, nil
+	case r == ',':
+		l.pos++
+		return token{kind: tokComma, text: ","}, nil
+	case r == '"' || r == '\'':
+		return l.lexString(r)
+	case r == '=' || r == '!' || r == '>' || r == '<':
+		return l.lexOperator()
+	case unicode.IsDigit(r):
+		return l.lexNumber()
+	case unicode.IsLetter(r) || r == '_':
+		return l.lexIdentOrKeyword()
+	default:
+		return token{}, fmt.Errorf("query: unexpected character %q at position %d", r, l.pos)
+	}
+}
+
+func (l *lexer) lexString(quote rune) (token, error) {
+	l.pos++ // consume opening quote
+	var sb strings.Builder
+	for {
+		r, ok := l.peekRune()
+		if !ok {
+			return token{}, fmt.Errorf("query: unterminated string literal")
+		}
+		l.pos++
+		if r == quote {
+			return token{kind: tokString, text: sb.String()}, nil
+		}
+		sb.WriteRune(r)
+	}
+}
+
+func (l *lexer) lexOperator() (token, error) {
+	start := l.pos
+	r := l.input[l.pos]
+	l.pos++
+	if next, ok := l.peekRune(); ok && next == '=' && (r == '=' || r == '!' || r == '>' || r == '<') {
+		l.pos++
+	}
+	return token{kind: tokOp, text: string(l.input[start:l.pos])}, nil
+}
+
+func (l *lexer) lexNumber() (token, error) {
+	start := l.pos
+	for {
+		r, ok := l.peekRune()
+		if !ok || !unicode.IsDigit(r) {
+			break
+		}
+		l.pos++
+	}
+	return token{kind: tokInt, text: string(l.input[start:l.pos])}, nil
+}
+
+func (l *lexer) lexIdentOrKeyword() (token, error) {
+	start := l.pos
+	for {
+		r, ok := l.peekRune()
+		if !ok || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-') {
+			break
+		}
+		l.pos++
+	}
+	word := string(l.input[start:l.pos])
+
+	switch strings.ToUpper(word) {
+	case "AND":
+		return token{kind: tokAnd, text: word}, nil
+	case "OR":
+		return token{kind: tokOr, text: word}, nil
+	case "NOT":
+		// "NOT IN" is handled by the parser peeking the following token;
+		// here NOT is always its own token.
+		return token{kind: tokNot, text: strings.ToUpper(word)}, nil
+	case "IN", "CONTAINS", "IS", "NULL":
+		return token{kind: tokOp, text: strings.ToUpper(word)}, nil
+	default:
+		return token{kind: tokIdent, text: word}, nil
+	}
+}
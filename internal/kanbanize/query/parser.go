@@ -0,0 +1,272 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// parser is a standard recursive-descent parser over the grammar:
+//
+//	expr   := orExpr
+//	orExpr := andExpr (OR andExpr)*
+//	andExpr:= unary (AND unary)*
+//	unary  := NOT unary | primary
+//	primary:= '(' expr ')' | cmp
+//	cmp    := IDENT op value
+//	op     := '=' | '!=' | IN | NOT IN | '>' | '<' | '>=' | '<=' | CONTAINS | IS NULL
+//	value  := STRING | INT | '(' value (',' value)* ')'
+type parser struct {
+	lex *lexer
+	cur token
+}
+
+// Parse parses a query string into an AST. An empty string is rejected;
+// callers should skip parsing (and filtering) entirely when no query is set.
+func Parse(input string) (Node, error) {
+	p := &parser{lex: newLexer(input)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.cur.kind == tokEOF {
+		return nil, fmt.Errorf("query: empty expression")
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.cur.kind != tokEOF {
+		return nil, fmt.Errorf("query: unexpected token %q after expression", p.cur.text)
+	}
+	return node, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.cur = tok
+	return nil
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur.kind == tokOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur.kind == tokAnd {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.cur.kind == tokNot {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	if p.cur.kind == tokLParen {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.cur.kind != tokRParen {
+			return nil, fmt.Errorf("query: expected ')', got %q", p.cur.text)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	return p.parseCmp()
+}
+
+func (p *parser) parseCmp() (Node, error) {
+	if p.cur.kind != tokIdent {
+		return nil, fmt.Errorf("query: expected field name, got %q", p.cur.text)
+	}
+	field := p.cur.text
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	op, err := p.parseOperator()
+	if err != nil {
+		return nil, err
+	}
+	if op == OpIsNull {
+		return Cmp{Field: field, Op: op}, nil
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return Cmp{Field: field, Op: op, Value: value}, nil
+}
+
+func (p *parser) parseOperator() (Operator, error) {
+	switch p.cur.text {
+	case "=":
+		return p.consumeOp(OpEq)
+	case "!=":
+		return p.consumeOp(OpNe)
+	case ">":
+		return p.consumeOp(OpGt)
+	case "<":
+		return p.consumeOp(OpLt)
+	case ">=":
+		return p.consumeOp(OpGe)
+	case "<=":
+		return p.consumeOp(OpLe)
+	case "IN":
+		return p.consumeOp(OpIn)
+	case "CONTAINS":
+		return p.consumeOp(OpContains)
+	case "NOT":
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		if p.cur.text != "IN" {
+			return "", fmt.Errorf("query: expected IN after NOT, got %q", p.cur.text)
+		}
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		return OpNotIn, nil
+	case "IS":
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		if p.cur.text != "NULL" {
+			return "", fmt.Errorf("query: expected NULL after IS, got %q", p.cur.text)
+		}
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		return OpIsNull, nil
+	default:
+		return "", fmt.Errorf("query: expected operator, got %q", p.cur.text)
+	}
+}
+
+func (p *parser) consumeOp(op Operator) (Operator, error) {
+	if err := p.advance(); err != nil {
+		return "", err
+	}
+	return op, nil
+}
+
+func (p *parser) parseValue() (Value, error) {
+	switch p.cur.kind {
+	case tokString:
+		v := parseLiteral(p.cur.text)
+		if err := p.advance(); err != nil {
+			return Value{}, err
+		}
+		return v, nil
+	case tokInt:
+		n, err := strconv.Atoi(p.cur.text)
+		if err != nil {
+			return Value{}, fmt.Errorf("query: invalid integer literal %q: %w", p.cur.text, err)
+		}
+		if err := p.advance(); err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: KindInt, Int: n}, nil
+	case tokLParen:
+		if err := p.advance(); err != nil {
+			return Value{}, err
+		}
+		var list []Value
+		for {
+			v, err := p.parseValue()
+			if err != nil {
+				return Value{}, err
+			}
+			list = append(list, v)
+			if p.cur.kind == tokComma {
+				if err := p.advance(); err != nil {
+					return Value{}, err
+				}
+				continue
+			}
+			break
+		}
+		if p.cur.kind != tokRParen {
+			return Value{}, fmt.Errorf("query: expected ')', got %q", p.cur.text)
+		}
+		if err := p.advance(); err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: KindList, List: list}, nil
+	default:
+		return Value{}, fmt.Errorf("query: expected value, got %q", p.cur.text)
+	}
+}
+
+// parseLiteral classifies a quoted string literal as an ISO-8601 date when it
+// parses as one, otherwise leaves it as a plain string.
+func parseLiteral(s string) Value {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Value{Kind: KindDate, Date: t, Str: s}
+		}
+	}
+	return Value{Kind: KindString, Str: s}
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import "testing"
+
+func TestParse_SimpleIn(t *testing.T) {
+	node, err := Parse(`board_ids IN (1,2)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cmp, ok := node.(Cmp)
+	if !ok {
+		t.Fatalf("expected Cmp node, got %T", node)
+	}
+	if cmp.Field != "board_ids" || cmp.Op != OpIn {
+		t.Errorf("unexpected node: %+v", cmp)
+	}
+	if len(cmp.Value.List) != 2 || cmp.Value.List[0].Int != 1 || cmp.Value.List[1].Int != 2 {
+		t.Errorf("unexpected value list: %+v", cmp.Value.List)
+	}
+}
+
+func TestParse_AndOrPrecedence(t *testing.T) {
+	node, err := Parse(`board_ids IN (1,2) AND (lane_ids = 7 OR workflow_ids IN (3,4))`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	and, ok := node.(And)
+	if !ok {
+		t.Fatalf("expected top-level And, got %T", node)
+	}
+	if _, ok := and.Right.(Or); !ok {
+		t.Errorf("expected right side of And to be Or, got %T", and.Right)
+	}
+}
+
+func TestParse_QuotedStringAndDate(t *testing.T) {
+	node, err := Parse(`assignee = "alice" AND updated_since > "2024-01-01"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	and, ok := node.(And)
+	if !ok {
+		t.Fatalf("expected And, got %T", node)
+	}
+	left := and.Left.(Cmp)
+	if left.Value.Kind != KindString || left.Value.Str != "alice" {
+		t.Errorf("unexpected left value: %+v", left.Value)
+	}
+	right := and.Right.(Cmp)
+	if right.Value.Kind != KindDate {
+		t.Errorf("expected date literal, got %+v", right.Value)
+	}
+}
+
+func TestParse_IsNullAndNot(t *testing.T) {
+	node, err := Parse(`NOT assignee IS NULL`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	not, ok := node.(Not)
+	if !ok {
+		t.Fatalf("expected Not, got %T", node)
+	}
+	cmp := not.Expr.(Cmp)
+	if cmp.Op != OpIsNull {
+		t.Errorf("expected IS NULL, got %v", cmp.Op)
+	}
+}
+
+func TestParse_InvalidSyntax(t *testing.T) {
+	if _, err := Parse(`board_ids IN (1,`); err == nil {
+		t.Error("expected error for unterminated list")
+	}
+	if _, err := Parse(``); err == nil {
+		t.Error("expected error for empty query")
+	}
+}
+
+func TestCompile_PushdownMergesNativeFields(t *testing.T) {
+	node, err := Parse(`board_ids IN (1,2) AND lane_ids = 7`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	compiled, err := Compile(node)
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if len(compiled.BoardIDs) != 2 || compiled.BoardIDs[0] != 1 || compiled.BoardIDs[1] != 2 {
+		t.Errorf("unexpected BoardIDs: %v", compiled.BoardIDs)
+	}
+	if len(compiled.LaneIDs) != 1 || compiled.LaneIDs[0] != 7 {
+		t.Errorf("unexpected LaneIDs: %v", compiled.LaneIDs)
+	}
+}
+
+func TestCompile_PushdownSkipsFieldsUnderOr(t *testing.T) {
+	node, err := Parse(`board_ids IN (1) OR lane_ids = 7`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	compiled, err := Compile(node)
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if len(compiled.BoardIDs) != 0 || len(compiled.LaneIDs) != 0 {
+		t.Errorf("expected no pushdown under OR, got boards=%v lanes=%v", compiled.BoardIDs, compiled.LaneIDs)
+	}
+}
+
+func TestCompile_PredicateEvaluatesNonNativeFields(t *testing.T) {
+	node, err := Parse(`assignee = "alice" AND NOT status IS NULL`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	compiled, err := Compile(node)
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+
+	match := map[string]interface{}{"assignee": "alice", "status": "open"}
+	if !compiled.Predicate(match) {
+		t.Error("expected predicate to match")
+	}
+
+	noAssignee := map[string]interface{}{"status": "open"}
+	if compiled.Predicate(noAssignee) {
+		t.Error("expected predicate to reject missing assignee")
+	}
+
+	nullStatus := map[string]interface{}{"assignee": "alice"}
+	if compiled.Predicate(nullStatus) {
+		t.Error("expected predicate to reject missing status")
+	}
+}
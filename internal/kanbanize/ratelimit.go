@@ -0,0 +1,309 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// wildcardCategory is consulted whenever a more specific category has no
+// active cooldown, and whenever a response can't be mapped to a category at
+// all.
+const wildcardCategory = "*"
+
+// RateLimitMap tracks, per endpoint category, the deadline before which
+// requests should be paused or skipped. Unlike the per-attempt RateLimitError
+// (which only reacts to the 429 that triggered it), RateLimitMap lets every
+// concurrent caller see a category's cooldown immediately, so a client
+// fetching many cards at once doesn't pile up 429s against a host it already
+// knows is rate limited.
+type RateLimitMap struct {
+	mu        sync.RWMutex
+	deadlines map[string]time.Time
+
+	// now is called instead of time.Now when computing a new deadline, so
+	// tests can advance rate-limit cooldowns deterministically without
+	// sleeping in real time. Defaults to time.Now.
+	now func() time.Time
+}
+
+// newRateLimitMap returns an empty RateLimitMap using the real wall clock.
+func newRateLimitMap() *RateLimitMap {
+	return &RateLimitMap{
+		deadlines: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+// deadline returns the later of the category's own deadline and the wildcard
+// deadline, since a wildcard cooldown (e.g. from an account-wide 429) applies
+// to every category.
+func (m *RateLimitMap) deadline(category string) time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	d := m.deadlines[category]
+	if wc := m.deadlines[wildcardCategory]; wc.After(d) {
+		d = wc
+	}
+	return d
+}
+
+// setDeadline records that category is cooling down until now+retryAfter,
+// never shortening an existing, later deadline for the same category.
+func (m *RateLimitMap) setDeadline(category string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		return
+	}
+	deadline := m.now().Add(retryAfter)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.deadlines[category]; !ok || deadline.After(existing) {
+		m.deadlines[category] = deadline
+	}
+}
+
+// snapshot returns a copy of all known deadlines for diagnostics.
+func (m *RateLimitMap) snapshot() map[string]time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make(map[string]time.Time, len(m.deadlines))
+	for k, v := range m.deadlines {
+		out[k] = v
+	}
+	return out
+}
+
+// RateLimitStatus returns the current rate-limit cooldown deadline per
+// category, for diagnostics/monitoring. A zero time.Time means no known
+// cooldown.
+func (c *Client) RateLimitStatus() map[string]time.Time {
+	return c.rateLimits.snapshot()
+}
+
+// categoryPatterns maps endpoint path shapes to the rate-limit category
+// BusinessMap scopes its limits by. Checked in order; the first match wins.
+var categoryPatterns = []struct {
+	pattern  *regexp.Regexp
+	category string
+}{
+	{regexp.MustCompile(`/cards/\d+/comments(?:/|$)`), "comments"},
+	{regexp.MustCompile(`/cards/\d+/subtasks(?:/|$)`), "subtasks"},
+	{regexp.MustCompile(`/cards/\d+(?:/|$)`), "card"},
+	{regexp.MustCompile(`/cards(?:\?|$)`), "board"},
+	{regexp.MustCompile(`/search(?:/|\?|$)`), "search"},
+}
+
+// categoryForURL derives the rate-limit category for a request URL, falling
+// back to the wildcard category when no pattern matches.
+func categoryForURL(rawURL string) string {
+	for _, cp := range categoryPatterns {
+		if cp.pattern.MatchString(rawURL) {
+			return cp.category
+		}
+	}
+	return wildcardCategory
+}
+
+// recordRateLimitResponse updates the rate-limit map from a response that
+// came back 429 (or carries a rate-limit header on a successful response,
+// which some APIs do proactively). It understands five header shapes, most
+// specific first:
+//
+//   - X-Sentry-Rate-Limits: "retry_after:categories:scope:reason, ...", a
+//     comma-separated list of groups, each applying its retry_after to every
+//     semicolon-separated category in that group.
+//   - X-RateLimit-Scope: "quota:categories:scope", e.g. "60:card,comments:organization",
+//     applied to every listed category regardless of which endpoint was called.
+//   - Retry-After: seconds or an HTTP-date, applied to the URL's own category.
+//   - X-RateLimit-Remaining / X-RateLimit-Reset: the common draft RateLimit
+//     header pair; once Remaining reaches 0, Reset (a Unix timestamp in
+//     seconds) is applied to the URL's own category.
+//   - X-Businessmap-RateLimit: a plain seconds count (BusinessMap's own quota
+//     header), applied to the URL's own category like Retry-After.
+func (c *Client) recordRateLimitResponse(rawURL string, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	if sentry := resp.Header.Get("X-Sentry-Rate-Limits"); sentry != "" {
+		if groups := parseSentryRateLimits(sentry); len(groups) > 0 {
+			for _, group := range groups {
+				categories := group.categories
+				if len(categories) == 0 {
+					categories = []string{wildcardCategory}
+				}
+				for _, cat := range categories {
+					c.rateLimits.setDeadline(cat, group.retryAfter)
+				}
+			}
+			return
+		}
+	}
+
+	if scope := resp.Header.Get("X-RateLimit-Scope"); scope != "" {
+		if quota, categories, ok := parseRateLimitScope(scope); ok {
+			for _, cat := range categories {
+				c.rateLimits.setDeadline(cat, quota)
+			}
+			return
+		}
+	}
+
+	if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After")); retryAfter > 0 {
+		c.rateLimits.setDeadline(categoryForURL(rawURL), retryAfter)
+		return
+	}
+
+	if retryAfter, ok := parseRateLimitRemainingReset(
+		resp.Header.Get("X-RateLimit-Remaining"),
+		resp.Header.Get("X-RateLimit-Reset"),
+		c.rateLimits.now,
+	); ok {
+		c.rateLimits.setDeadline(categoryForURL(rawURL), retryAfter)
+		return
+	}
+
+	if quota := parseBusinessmapRateLimit(resp.Header.Get("X-Businessmap-RateLimit")); quota > 0 {
+		c.rateLimits.setDeadline(categoryForURL(rawURL), quota)
+	}
+}
+
+// parseRateLimitRemainingReset applies the common draft RateLimit header
+// pair: once remaining reaches 0, reset (a Unix timestamp in seconds) is
+// converted to a duration via now(). ok is false if remaining is missing,
+// nonzero, or either header fails to parse.
+func parseRateLimitRemainingReset(remaining, reset string, now func() time.Time) (time.Duration, bool) {
+	if remaining == "" {
+		return 0, false
+	}
+	left, err := strconv.Atoi(strings.TrimSpace(remaining))
+	if err != nil || left > 0 {
+		return 0, false
+	}
+
+	resetSeconds, err := strconv.ParseInt(strings.TrimSpace(reset), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	retryAfter := time.Unix(resetSeconds, 0).Sub(now())
+	if retryAfter <= 0 {
+		return 0, false
+	}
+	return retryAfter, true
+}
+
+// sentryRateLimitGroup is one comma-separated group of an
+// X-Sentry-Rate-Limits header.
+type sentryRateLimitGroup struct {
+	retryAfter time.Duration
+	categories []string
+}
+
+// parseSentryRateLimits parses Sentry's rate-limit header format:
+// "retry_after:categories:scope:reason_code, retry_after:categories:scope:reason_code, ...",
+// where categories is a semicolon-separated list, empty meaning "every
+// category" (reported by the caller as wildcardCategory). Malformed groups
+// are skipped rather than failing the whole header.
+func parseSentryRateLimits(header string) []sentryRateLimitGroup {
+	var groups []sentryRateLimitGroup
+
+	for _, rawGroup := range strings.Split(header, ",") {
+		rawGroup = strings.TrimSpace(rawGroup)
+		if rawGroup == "" {
+			continue
+		}
+
+		fields := strings.Split(rawGroup, ":")
+		if len(fields) < 1 {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil || seconds <= 0 {
+			continue
+		}
+
+		var categories []string
+		if len(fields) >= 2 {
+			for _, cat := range strings.Split(fields[1], ";") {
+				cat = strings.TrimSpace(cat)
+				if cat != "" {
+					categories = append(categories, cat)
+				}
+			}
+		}
+
+		groups = append(groups, sentryRateLimitGroup{
+			retryAfter: time.Duration(seconds) * time.Second,
+			categories: categories,
+		})
+	}
+
+	return groups
+}
+
+// parseBusinessmapRateLimit parses BusinessMap's vendor quota header, a bare
+// count of seconds to cool down for (e.g. "30"). An empty or malformed value
+// yields 0, meaning no cooldown should be recorded.
+func parseBusinessmapRateLimit(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// parseRateLimitScope parses the "quota:categories:scope" structured header
+// format into a quota duration and the list of categories it applies to. The
+// trailing scope segment (e.g. "organization") is accepted but not
+// interpreted; it's informational only for now.
+func parseRateLimitScope(header string) (time.Duration, []string, bool) {
+	parts := strings.Split(header, ":")
+	if len(parts) < 2 {
+		return 0, nil, false
+	}
+
+	seconds, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || seconds <= 0 {
+		return 0, nil, false
+	}
+
+	var categories []string
+	for _, cat := range strings.Split(parts[1], ",") {
+		cat = strings.TrimSpace(cat)
+		if cat != "" {
+			categories = append(categories, cat)
+		}
+	}
+	if len(categories) == 0 {
+		return 0, nil, false
+	}
+
+	return time.Duration(seconds) * time.Second, categories, true
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCategoryForURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/api/v2/cards/1001/comments", "comments"},
+		{"https://example.com/api/v2/cards/1001/subtasks", "subtasks"},
+		{"https://example.com/api/v2/cards/1001", "card"},
+		{"https://example.com/api/v2/cards?board_ids=1,2", "board"},
+		{"https://example.com/api/v2/search?q=foo", "search"},
+		{"https://example.com/api/v2/workspaces", "*"},
+	}
+	for _, tt := range tests {
+		if got := categoryForURL(tt.url); got != tt.want {
+			t.Errorf("categoryForURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestParseRateLimitScope(t *testing.T) {
+	quota, categories, ok := parseRateLimitScope("60:card,comments:organization")
+	if !ok {
+		t.Fatal("expected header to parse")
+	}
+	if quota != 60*time.Second {
+		t.Errorf("expected quota 60s, got %v", quota)
+	}
+	if len(categories) != 2 || categories[0] != "card" || categories[1] != "comments" {
+		t.Errorf("unexpected categories: %v", categories)
+	}
+
+	if _, _, ok := parseRateLimitScope("not-a-header"); ok {
+		t.Error("expected malformed header to fail to parse")
+	}
+}
+
+func TestParseBusinessmapRateLimit(t *testing.T) {
+	if got := parseBusinessmapRateLimit("30"); got != 30*time.Second {
+		t.Errorf("parseBusinessmapRateLimit(30) = %v, want 30s", got)
+	}
+	if got := parseBusinessmapRateLimit(""); got != 0 {
+		t.Errorf("expected 0 for empty header, got %v", got)
+	}
+	if got := parseBusinessmapRateLimit("not-a-number"); got != 0 {
+		t.Errorf("expected 0 for malformed header, got %v", got)
+	}
+}
+
+func TestRecordRateLimitResponse_BusinessmapHeader(t *testing.T) {
+	client := NewClient("http://example.com", "test-api-key")
+	resp := &http.Response{Header: http.Header{"X-Businessmap-Ratelimit": []string{"45"}}}
+
+	client.recordRateLimitResponse("http://example.com/api/v2/cards/1001", resp)
+
+	deadline := client.rateLimits.deadline("card")
+	if deadline.IsZero() {
+		t.Fatal("expected a cooldown to be recorded for the card category")
+	}
+	if time.Until(deadline) > 45*time.Second || time.Until(deadline) < 40*time.Second {
+		t.Errorf("expected cooldown of roughly 45s, got %v remaining", time.Until(deadline))
+	}
+}
+
+func TestParseRateLimitRemainingReset(t *testing.T) {
+	fixedNow := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	now := func() time.Time { return fixedNow }
+
+	reset := fixedNow.Add(30 * time.Second).Unix()
+	got, ok := parseRateLimitRemainingReset("0", strconv.FormatInt(reset, 10), now)
+	if !ok {
+		t.Fatal("expected remaining=0 with a future reset to produce a cooldown")
+	}
+	if got < 29*time.Second || got > 30*time.Second {
+		t.Errorf("expected ~30s cooldown, got %v", got)
+	}
+
+	if _, ok := parseRateLimitRemainingReset("5", strconv.FormatInt(reset, 10), now); ok {
+		t.Error("expected a nonzero remaining to produce no cooldown")
+	}
+	if _, ok := parseRateLimitRemainingReset("", strconv.FormatInt(reset, 10), now); ok {
+		t.Error("expected a missing remaining header to produce no cooldown")
+	}
+	if _, ok := parseRateLimitRemainingReset("0", "not-a-number", now); ok {
+		t.Error("expected a malformed reset header to produce no cooldown")
+	}
+
+	past := fixedNow.Add(-30 * time.Second).Unix()
+	if _, ok := parseRateLimitRemainingReset("0", strconv.FormatInt(past, 10), now); ok {
+		t.Error("expected a reset already in the past to produce no cooldown")
+	}
+}
+
+func TestParseSentryRateLimits(t *testing.T) {
+	groups := parseSentryRateLimits("60:error;transaction:organization, 2700:default:organization")
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d: %+v", len(groups), groups)
+	}
+
+	if groups[0].retryAfter != 60*time.Second {
+		t.Errorf("expected first group retryAfter=60s, got %v", groups[0].retryAfter)
+	}
+	if len(groups[0].categories) != 2 || groups[0].categories[0] != "error" || groups[0].categories[1] != "transaction" {
+		t.Errorf("unexpected categories for first group: %v", groups[0].categories)
+	}
+
+	if groups[1].retryAfter != 2700*time.Second {
+		t.Errorf("expected second group retryAfter=2700s, got %v", groups[1].retryAfter)
+	}
+	if len(groups[1].categories) != 1 || groups[1].categories[0] != "default" {
+		t.Errorf("unexpected categories for second group: %v", groups[1].categories)
+	}
+
+	if groups := parseSentryRateLimits(""); groups != nil {
+		t.Errorf("expected no groups for an empty header, got %v", groups)
+	}
+	if groups := parseSentryRateLimits("not-a-number:default"); groups != nil {
+		t.Errorf("expected a malformed group to be skipped, got %v", groups)
+	}
+}
+
+func TestRecordRateLimitResponse_SentryHeaderAppliesToAllListedCategories(t *testing.T) {
+	client := NewClient("http://example.com", "test-api-key")
+	resp := &http.Response{Header: http.Header{"X-Sentry-Rate-Limits": []string{"30:card;comments::key"}}}
+
+	client.recordRateLimitResponse("http://example.com/api/v2/cards/1001", resp)
+
+	for _, category := range []string{"card", "comments"} {
+		if client.rateLimits.deadline(category).IsZero() {
+			t.Errorf("expected a cooldown to be recorded for category %q", category)
+		}
+	}
+}
+
+func TestRecordRateLimitResponse_StandardRemainingResetHeaders(t *testing.T) {
+	fixedNow := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	client := NewClient("http://example.com", "test-api-key")
+	client.rateLimits.now = func() time.Time { return fixedNow }
+
+	reset := fixedNow.Add(20 * time.Second).Unix()
+	resp := &http.Response{Header: http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(reset, 10)},
+	}}
+
+	client.recordRateLimitResponse("http://example.com/api/v2/cards/1001", resp)
+
+	deadline := client.rateLimits.deadline("card")
+	if deadline.IsZero() {
+		t.Fatal("expected a cooldown to be recorded for the card category")
+	}
+	if !deadline.Equal(fixedNow.Add(20 * time.Second)) {
+		t.Errorf("expected deadline = %v, got %v", fixedNow.Add(20*time.Second), deadline)
+	}
+}
+
+func TestRateLimitMap_SetDeadlineUsesInjectedNow(t *testing.T) {
+	fixedNow := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	m := newRateLimitMap()
+	m.now = func() time.Time { return fixedNow }
+
+	m.setDeadline("card", 10*time.Second)
+
+	if got := m.deadline("card"); !got.Equal(fixedNow.Add(10 * time.Second)) {
+		t.Errorf("expected deadline = %v, got %v", fixedNow.Add(10*time.Second), got)
+	}
+}
+
+func TestClient_SkipIfRateLimited(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": "rate limited"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := RetryConfig{
+		MaxAttempts:       3,
+		InitialDelay:      10 * time.Millisecond,
+		MaxDelay:          10 * time.Millisecond,
+		Multiplier:        2.0,
+		RespectRetryAfter: true,
+		RetryAfterMax:     time.Minute,
+		TotalWaitCap:      time.Second,
+	}
+
+	ctx := context.Background()
+	url := server.URL + "/api/v2/cards/1001"
+
+	if _, err := client.makeRequestWithRetry(ctx, cfg, "GET", url, nil); err == nil {
+		t.Fatal("expected first call to fail with a rate limit error")
+	}
+
+	status := client.RateLimitStatus()
+	if status["card"].IsZero() {
+		t.Fatal("expected the card category to have a recorded cooldown")
+	}
+
+	cfg.SkipIfRateLimited = true
+	requestsBeforeSkip := requestCount
+
+	_, err := client.makeRequestWithRetry(ctx, cfg, "GET", url, nil)
+	if err == nil {
+		t.Fatal("expected the second call to fail fast with ErrRateLimited")
+	}
+	var rlErr *ErrRateLimited
+	if rl, ok := err.(*ErrRateLimited); ok {
+		rlErr = rl
+	}
+	if rlErr == nil {
+		t.Fatalf("expected *ErrRateLimited, got %v (%T)", err, err)
+	}
+	if rlErr.Category != "card" {
+		t.Errorf("expected category 'card', got %q", rlErr.Category)
+	}
+
+	if requestCount != requestsBeforeSkip {
+		t.Errorf("expected no new HTTP requests once skipping, went from %d to %d", requestsBeforeSkip, requestCount)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter lets a caller throttle outgoing requests proactively, instead
+// of only reacting to a 429 after it's already happened (see RateLimitMap).
+// Install one via Client.SetRateLimiter. Modeled on resty's RateLimiter
+// interface so an existing resty limiter implementation can be reused here
+// unmodified.
+type RateLimiter interface {
+	// Wait blocks until a request may proceed, or ctx is done.
+	Wait(ctx context.Context) error
+	// Allow reports whether a request may proceed right now, without
+	// blocking or consuming from a future window.
+	Allow() bool
+}
+
+// TokenBucketRateLimiter is a RateLimiter backed by golang.org/x/time/rate: a
+// bucket refilling at r tokens/sec, holding up to burst tokens.
+type TokenBucketRateLimiter struct {
+	limiter *rate.Limiter
+}
+
+// NewTokenBucketRateLimiter returns a TokenBucketRateLimiter allowing r
+// requests/sec on average, with bursts up to burst requests.
+func NewTokenBucketRateLimiter(r float64, burst int) *TokenBucketRateLimiter {
+	return &TokenBucketRateLimiter{limiter: rate.NewLimiter(rate.Limit(r), burst)}
+}
+
+func (l *TokenBucketRateLimiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+func (l *TokenBucketRateLimiter) Allow() bool {
+	return l.limiter.Allow()
+}
+
+// SlidingWindowRateLimiter is a RateLimiter that enforces independent caps on
+// requests per second and requests per minute, each tracked over its own
+// trailing window. Unlike TokenBucketRateLimiter it has no separate burst
+// parameter: PerSecond itself bounds how bursty traffic may be.
+type SlidingWindowRateLimiter struct {
+	perSecond int
+	perMinute int
+
+	now func() time.Time
+
+	mu           sync.Mutex
+	secondWindow []time.Time
+	minuteWindow []time.Time
+}
+
+// NewSlidingWindowRateLimiter returns a SlidingWindowRateLimiter capping
+// requests to perSecond per rolling second and perMinute per rolling minute.
+// A zero value for either disables that particular cap.
+func NewSlidingWindowRateLimiter(perSecond, perMinute int) *SlidingWindowRateLimiter {
+	return &SlidingWindowRateLimiter{
+		perSecond: perSecond,
+		perMinute: perMinute,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a request may proceed right now under both the
+// per-second and per-minute caps, recording it if so.
+func (l *SlidingWindowRateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.secondWindow = evictBefore(l.secondWindow, now.Add(-time.Second))
+	l.minuteWindow = evictBefore(l.minuteWindow, now.Add(-time.Minute))
+
+	if l.perSecond > 0 && len(l.secondWindow) >= l.perSecond {
+		return false
+	}
+	if l.perMinute > 0 && len(l.minuteWindow) >= l.perMinute {
+		return false
+	}
+
+	l.secondWindow = append(l.secondWindow, now)
+	l.minuteWindow = append(l.minuteWindow, now)
+	return true
+}
+
+// Wait blocks, polling at a small fixed interval, until Allow returns true or
+// ctx is done.
+func (l *SlidingWindowRateLimiter) Wait(ctx context.Context) error {
+	const pollInterval = 10 * time.Millisecond
+
+	for {
+		if l.Allow() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// evictBefore returns the suffix of window whose timestamps are >= cutoff.
+// window is assumed sorted ascending, which holds since entries are always
+// appended with the current time.
+func evictBefore(window []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(window) && window[i].Before(cutoff) {
+		i++
+	}
+	return window[i:]
+}
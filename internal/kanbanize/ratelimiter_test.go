@@ -0,0 +1,180 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var errRateLimiterRefused = errors.New("rate limiter refused the request")
+
+func TestTokenBucketRateLimiter_AllowRespectsBurst(t *testing.T) {
+	limiter := NewTokenBucketRateLimiter(1, 2)
+
+	if !limiter.Allow() {
+		t.Fatal("expected first request within burst to be allowed")
+	}
+	if !limiter.Allow() {
+		t.Fatal("expected second request within burst to be allowed")
+	}
+	if limiter.Allow() {
+		t.Fatal("expected third request to exceed burst and be denied")
+	}
+}
+
+func TestTokenBucketRateLimiter_WaitUnblocksAfterRefill(t *testing.T) {
+	limiter := NewTokenBucketRateLimiter(100, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("expected first Wait to succeed immediately, got %v", err)
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("expected second Wait to succeed after refill, got %v", err)
+	}
+}
+
+func TestTokenBucketRateLimiter_WaitReturnsErrOnCanceledContext(t *testing.T) {
+	limiter := NewTokenBucketRateLimiter(0.001, 1)
+	limiter.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err == nil {
+		t.Fatal("expected Wait to return an error once the context deadline is exceeded")
+	}
+}
+
+func TestSlidingWindowRateLimiter_AllowRespectsPerSecondCap(t *testing.T) {
+	fixedNow := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewSlidingWindowRateLimiter(2, 0)
+	limiter.now = func() time.Time { return fixedNow }
+
+	if !limiter.Allow() || !limiter.Allow() {
+		t.Fatal("expected first two requests within the per-second cap to be allowed")
+	}
+	if limiter.Allow() {
+		t.Fatal("expected third request within the same second to be denied")
+	}
+
+	fixedNow = fixedNow.Add(1100 * time.Millisecond)
+	if !limiter.Allow() {
+		t.Fatal("expected a request a second later to be allowed once the window rolls over")
+	}
+}
+
+func TestSlidingWindowRateLimiter_AllowRespectsPerMinuteCap(t *testing.T) {
+	fixedNow := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewSlidingWindowRateLimiter(0, 1)
+	limiter.now = func() time.Time { return fixedNow }
+
+	if !limiter.Allow() {
+		t.Fatal("expected first request within the per-minute cap to be allowed")
+	}
+
+	fixedNow = fixedNow.Add(time.Second)
+	if limiter.Allow() {
+		t.Fatal("expected second request within the same minute to be denied")
+	}
+
+	fixedNow = fixedNow.Add(time.Minute)
+	if !limiter.Allow() {
+		t.Fatal("expected a request a minute later to be allowed once the window rolls over")
+	}
+}
+
+func TestSlidingWindowRateLimiter_WaitReturnsErrOnCanceledContext(t *testing.T) {
+	limiter := NewSlidingWindowRateLimiter(1, 0)
+	limiter.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err == nil {
+		t.Fatal("expected Wait to return an error once the context deadline is exceeded")
+	}
+}
+
+// blockingRateLimiter is a test double that reports how many times Wait was
+// called and always returns a fixed error, so we can assert SetRateLimiter's
+// Wait call is wired into the request path.
+type blockingRateLimiter struct {
+	waitCalls int
+	err       error
+}
+
+func (l *blockingRateLimiter) Wait(ctx context.Context) error {
+	l.waitCalls++
+	return l.err
+}
+
+func (l *blockingRateLimiter) Allow() bool {
+	return l.err == nil
+}
+
+func TestClient_SetRateLimiter_WaitBlocksOutgoingRequests(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	limiter := &blockingRateLimiter{err: errRateLimiterRefused}
+	client.SetRateLimiter(limiter)
+
+	cfg := DefaultRetryConfig()
+	cfg.MaxAttempts = 1
+
+	_, err := client.makeRequestWithRetry(context.Background(), cfg, "GET", server.URL+"/api/v2/cards/1001", nil)
+	if err == nil {
+		t.Fatal("expected the rate limiter's refusal to surface as an error")
+	}
+	if limiter.waitCalls == 0 {
+		t.Fatal("expected SetRateLimiter's limiter to be consulted before the request was sent")
+	}
+	if requestCount != 0 {
+		t.Errorf("expected the HTTP request to be skipped entirely, but the server saw %d", requestCount)
+	}
+}
+
+func TestClient_SetRateLimiter_AllowsRequestsWhenNil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+
+	cfg := DefaultRetryConfig()
+	cfg.MaxAttempts = 1
+
+	if _, err := client.makeRequestWithRetry(context.Background(), cfg, "GET", server.URL+"/api/v2/cards/1001", nil); err != nil {
+		t.Fatalf("expected request to succeed without a rate limiter installed, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hivemq/businessmap-mcp/internal/kanbanize/option"
+)
+
+// RequestOption is re-exported from the option subpackage so method
+// signatures read kanbanize.RequestOption while callers construct instances
+// via option.WithAPIKey, option.WithHTTPHeader, and so on. It lives in its
+// own subpackage (see option.go's doc comment) so its constructors can reuse
+// names already taken by the Client-construction Option type in client.go.
+type RequestOption = option.RequestOption
+
+// requestOverrides carries a call's resolved RequestOptions through ctx, so
+// deeply-nested helpers (doRequest, getCardContext, FetchGroup's specs, ...)
+// can see them without every function in the call chain growing new
+// parameters for every new option. It is only ever read back by the
+// kanbanize package itself.
+type requestOverrides struct {
+	apiKey         string
+	httpClient     *http.Client
+	baseURL        string
+	headers        http.Header
+	maxAttempts    int
+	initialDelay   time.Duration
+	idempotencyKey string
+}
+
+type requestOverridesKey struct{}
+
+// withRequestOptions folds every opt into a requestOverrides (later opts win)
+// and returns a ctx carrying the result; a WithContext option, if present,
+// replaces ctx itself first (so e.g. ReadCard, which otherwise only has
+// context.Background() to work with, can still be bounded by a caller).
+func withRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	var cfg option.Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.Context != nil {
+		ctx = cfg.Context
+	}
+
+	idempotencyKey := cfg.IdempotencyKey
+	if idempotencyKey == "" && cfg.AutoIdempotency {
+		idempotencyKey = newIdempotencyKey()
+	}
+
+	overrides := &requestOverrides{
+		apiKey:         cfg.APIKey,
+		httpClient:     cfg.HTTPClient,
+		baseURL:        strings.TrimSuffix(cfg.BaseURL, "/"),
+		headers:        cfg.Headers,
+		maxAttempts:    cfg.MaxAttempts,
+		initialDelay:   cfg.InitialDelay,
+		idempotencyKey: idempotencyKey,
+	}
+	return context.WithValue(ctx, requestOverridesKey{}, overrides)
+}
+
+func requestOverridesFromContext(ctx context.Context) *requestOverrides {
+	overrides, _ := ctx.Value(requestOverridesKey{}).(*requestOverrides)
+	return overrides
+}
+
+// apiKeyFor returns the API key this call should use: a RequestOption
+// override if one was given, otherwise the Client's own.
+func (c *Client) apiKeyFor(ctx context.Context) string {
+	if o := requestOverridesFromContext(ctx); o != nil && o.apiKey != "" {
+		return o.apiKey
+	}
+	return c.apiKey
+}
+
+// httpClientFor returns the http.Client this call should use.
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	if o := requestOverridesFromContext(ctx); o != nil && o.httpClient != nil {
+		return o.httpClient
+	}
+	return c.httpClient
+}
+
+// baseURLFor returns the BusinessMap base URL this call should use.
+func (c *Client) baseURLFor(ctx context.Context) string {
+	if o := requestOverridesFromContext(ctx); o != nil && o.baseURL != "" {
+		return o.baseURL
+	}
+	return c.baseURL
+}
+
+// extraHeadersFor returns any additional headers a RequestOption asked to be
+// set on the outbound request, or nil.
+func extraHeadersFor(ctx context.Context) http.Header {
+	if o := requestOverridesFromContext(ctx); o != nil {
+		return o.headers
+	}
+	return nil
+}
+
+// idempotencyKeyFor returns the idempotency key this call should use, either
+// an explicit option.WithIdempotencyKey or one generated by
+// option.WithAutoIdempotency, or "" if neither was given.
+func idempotencyKeyFor(ctx context.Context) string {
+	if o := requestOverridesFromContext(ctx); o != nil {
+		return o.idempotencyKey
+	}
+	return ""
+}
+
+// retryConfigFor applies a WithMaxAttempts/WithInitialDelay RequestOption
+// override, if any, on top of base.
+func retryConfigFor(ctx context.Context, base RetryConfig) RetryConfig {
+	o := requestOverridesFromContext(ctx)
+	if o == nil {
+		return base
+	}
+	if o.maxAttempts > 0 {
+		base.MaxAttempts = o.maxAttempts
+	}
+	if o.initialDelay > 0 {
+		base.InitialDelay = o.initialDelay
+	}
+	return base
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hivemq/businessmap-mcp/internal/kanbanize/option"
+)
+
+func TestReadCardContext_WithAPIKeyOption(t *testing.T) {
+	var gotAPIKey string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAPIKey = r.Header.Get("apikey")
+		switch r.URL.Path {
+		case "/api/v2/cards/1001":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CardDataResponse{Data: CardData{CardID: 1001, Title: "Test Card"}})
+		case "/api/v2/cards/1001/comments":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CommentsResponse{Data: []CommentData{}})
+		case "/api/v2/cards/1001/subtasks":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(SubtasksResponse{Data: []SubtaskData{}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "default-api-key")
+	_, err := client.ReadCardContext(context.Background(), "1001", option.WithAPIKey("override-api-key"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotAPIKey != "override-api-key" {
+		t.Errorf("Expected request to use overridden API key, got %q", gotAPIKey)
+	}
+}
+
+func TestReadCardContext_WithBaseURLOption(t *testing.T) {
+	var hitOverrideServer bool
+
+	overrideServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitOverrideServer = true
+		switch r.URL.Path {
+		case "/api/v2/cards/1001":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CardDataResponse{Data: CardData{CardID: 1001, Title: "Override Card"}})
+		case "/api/v2/cards/1001/comments":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CommentsResponse{Data: []CommentData{}})
+		case "/api/v2/cards/1001/subtasks":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(SubtasksResponse{Data: []SubtaskData{}})
+		}
+	}))
+	defer overrideServer.Close()
+
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected request to go to the overridden base URL, got a request to the default server at %s", r.URL.Path)
+	}))
+	defer defaultServer.Close()
+
+	client := NewClient(defaultServer.URL, "test-api-key")
+	response, err := client.ReadCardContext(context.Background(), "1001", option.WithBaseURL(overrideServer.URL))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !hitOverrideServer {
+		t.Fatal("Expected the overridden base URL's server to receive the request")
+	}
+	if response.Title != "Override Card" {
+		t.Errorf("Expected title 'Override Card', got '%s'", response.Title)
+	}
+}
+
+func TestAddCardCommentContext_WithHTTPHeaderOption(t *testing.T) {
+	var gotHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Custom-Header")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AddCommentResponse{})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	err := client.AddCardCommentContext(context.Background(), "1001", "hello", option.WithHTTPHeader("X-Custom-Header", "custom-value"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotHeader != "custom-value" {
+		t.Errorf("Expected X-Custom-Header to be 'custom-value', got %q", gotHeader)
+	}
+}
+
+func TestReadCardWithRetry_WithMaxAttemptsOption(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/cards/1001" {
+			attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CommentsResponse{Data: []CommentData{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithClock(newFakeClock()))
+	cfg := defaultClientRetryConfig()
+	cfg.InitialDelay = time.Millisecond
+
+	_, err := client.ReadCardWithRetry(context.Background(), "1001", cfg, false, option.WithMaxAttempts(1))
+	if err == nil {
+		t.Fatal("Expected an error because the card endpoint always fails")
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected WithMaxAttempts(1) to limit the card fetch to 1 attempt, got %d", attempts)
+	}
+}
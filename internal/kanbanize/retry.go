@@ -19,15 +19,73 @@ package kanbanize
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"math"
 	"math/rand"
+	"net"
 	"net/http"
 	"strconv"
 	"time"
 )
 
+// JitterMode selects how exponential backoff delays are randomized.
+type JitterMode int
+
+const (
+	// JitterFull picks a delay uniformly between 0 and the full backoff.
+	JitterFull JitterMode = iota
+	// JitterNone uses the computed backoff with no randomization.
+	JitterNone
+	// JitterEqual picks a delay uniformly between half the backoff and the
+	// full backoff, trading off thundering-herd avoidance for a higher floor.
+	JitterEqual
+	// JitterDecorrelated implements the AWS "decorrelated jitter" scheme:
+	// each delay is picked uniformly between the base delay and 3x the
+	// previous delay, capped at MaxDelay/MaxBackoff. Unlike the other modes
+	// it depends on the previous attempt's delay rather than the attempt
+	// number, which further spreads out clients that started retrying in
+	// lockstep.
+	JitterDecorrelated
+	// JitterConstant always waits exactly InitialDelay/BaseBackoff, with no
+	// exponential growth and no randomization. It exists for deterministic
+	// tests (combined with WithClock) that need a fixed, predictable delay
+	// rather than production traffic's thundering-herd avoidance.
+	JitterConstant
+)
+
+// defaultRetryableStatusCodes are the HTTP status patterns the general retry
+// transport treats as transient by default, beyond the dedicated 429 path.
+// Each entry is either an exact status code ("502") or a wildcard class
+// ("5xx", matching every status in that hundred) — see statusCodeMatches.
+var defaultRetryableStatusCodes = []string{
+	strconv.Itoa(http.StatusTooManyRequests),
+	strconv.Itoa(http.StatusBadGateway),
+	strconv.Itoa(http.StatusServiceUnavailable),
+	strconv.Itoa(http.StatusGatewayTimeout),
+}
+
+// defaultClientRetryConfig is used internally by the plain ReadCard /
+// AddCardComment calls, which don't expose retry tuning to the caller. It
+// retries a handful of times with a short backoff; callers that need the
+// full tunable surface (attempts, delays, Retry-After handling) should use
+// ReadCardWithRetry / GetCardsWithRetry instead.
+func defaultClientRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:       4,
+		InitialDelay:      200 * time.Millisecond,
+		MaxDelay:          5 * time.Second,
+		Multiplier:        2.0,
+		RespectRetryAfter: true,
+		TotalWaitCap:      30 * time.Second,
+		BaseBackoff:       200 * time.Millisecond,
+		MaxBackoff:        5 * time.Second,
+		JitterMode:        JitterFull,
+		RetryStatusCodes:  defaultRetryableStatusCodes,
+		RetryAfterMax:     5 * time.Second,
+	}
+}
+
 // RetryConfig holds configuration for retry behavior
 type RetryConfig struct {
 	MaxAttempts       int
@@ -35,7 +93,66 @@ type RetryConfig struct {
 	MaxDelay          time.Duration
 	Multiplier        float64
 	RespectRetryAfter bool
-	TotalWaitCap      time.Duration
+
+	// TotalWaitCap is the wait budget makeRequestWithRetry/fetchWithRetry
+	// enforce when the ctx passed in carries no deadline of its own (see
+	// ensureRetryDeadline): it's used to derive one via context.WithTimeout,
+	// making ctx's deadline the single authoritative budget either way. A
+	// ctx with its own deadline or cancellation always takes precedence over
+	// this fallback. It's also still used directly as the shared wait budget
+	// for FetchGroup's concurrent fan-out (see sharedBudget).
+	TotalWaitCap time.Duration
+
+	// BaseBackoff and MaxBackoff drive the general (non-rate-limit) retry
+	// transport's exponential backoff: base * multiplier^attempt, capped at
+	// MaxBackoff. They default to InitialDelay/MaxDelay when zero.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// JitterMode selects how the computed backoff is randomized.
+	JitterMode JitterMode
+
+	// RetryStatusCodes lists the HTTP status patterns the general retry
+	// transport treats as transient. Each entry is either an exact code
+	// ("408") or a wildcard class ("5xx", matching every status in that
+	// hundred) — see statusCodeMatches. When set, it fully replaces
+	// defaultRetryableStatusCodes (429/502/503/504) rather than adding to it,
+	// so an operator behind an authenticating proxy who also wants 407 or 408
+	// retried must list the defaults they still want alongside them, e.g.
+	// []string{"429", "5xx", "407", "408"}. When empty, the client falls
+	// back to defaultRetryableStatusCodes.
+	RetryStatusCodes []string
+
+	// ShouldRetry, if set, overrides the default retryability classification
+	// for a given response/error pair.
+	ShouldRetry func(resp *http.Response, err error) bool
+
+	// SkipIfRateLimited short-circuits with an *ErrRateLimited, without
+	// consuming an attempt or a circuit-breaker failure, when the client's
+	// proactive RateLimitMap already has the request's category cooling
+	// down. When false (the default), the call instead waits out the
+	// cooldown like any other backoff.
+	SkipIfRateLimited bool
+
+	// RetryAfterMax caps how long a server-supplied Retry-After value (on a
+	// 429, or via RateLimitMap) is allowed to delay a retry. The zero value
+	// means the header is ignored entirely, equivalent to
+	// RespectRetryAfter=false for that value only — this protects against a
+	// hostile or misconfigured server returning a multi-hour Retry-After.
+	// Callers that want Retry-After honored must set RetryAfterMax
+	// explicitly.
+	RetryAfterMax time.Duration
+
+	// MaxConcurrency bounds how many of a FetchGroup's sub-fetches (see
+	// FetchGroup, used by ReadCardWithRetry) may have an HTTP attempt in
+	// flight at once. The zero value means every spec in the group gets its
+	// own slot, i.e. no gating.
+	MaxConcurrency int
+
+	// Observer receives retry-lifecycle notifications (attempts, backoff,
+	// give-up, success) instead of the retry loops logging directly. The
+	// zero value falls back to LogObserver.
+	Observer RetryObserver
 }
 
 // DefaultRetryConfig returns sensible default retry configuration
@@ -47,6 +164,11 @@ func DefaultRetryConfig() RetryConfig {
 		Multiplier:        2.0,
 		RespectRetryAfter: true,
 		TotalWaitCap:      20 * time.Minute,
+		BaseBackoff:       5 * time.Second,
+		MaxBackoff:        5 * time.Minute,
+		JitterMode:        JitterFull,
+		RetryStatusCodes:  defaultRetryableStatusCodes,
+		RetryAfterMax:     5 * time.Minute,
 	}
 }
 
@@ -67,6 +189,12 @@ func (rc *RetryConfig) Validate() error {
 	if rc.TotalWaitCap < rc.InitialDelay {
 		return fmt.Errorf("TotalWaitCap (%v) must be >= InitialDelay (%v)", rc.TotalWaitCap, rc.InitialDelay)
 	}
+	if rc.RetryAfterMax < 0 {
+		return fmt.Errorf("RetryAfterMax must be >= 0, got %v", rc.RetryAfterMax)
+	}
+	if rc.JitterMode < JitterFull || rc.JitterMode > JitterConstant {
+		return fmt.Errorf("JitterMode must be one of JitterFull, JitterNone, JitterEqual, JitterDecorrelated, JitterConstant, got %d", rc.JitterMode)
+	}
 	return nil
 }
 
@@ -103,33 +231,126 @@ func parseRetryAfter(retryAfterHeader string) time.Duration {
 	return 0
 }
 
-// exponentialBackoffWithJitter calculates the backoff delay using full jitter
-// Returns the delay to wait before the next retry attempt
-func exponentialBackoffWithJitter(cfg RetryConfig, attempt int, retryAfter time.Duration) time.Duration {
-	// If Retry-After header is present and we respect it, use it
-	if retryAfter > 0 && cfg.RespectRetryAfter {
+// exponentialBackoffWithJitter calculates the backoff delay for a retry
+// attempt, applying cfg.JitterMode (default full jitter) on top of the
+// exponential curve. prevDelay is the delay returned for the previous
+// attempt (zero for the first), and is only consulted by JitterDecorrelated;
+// callers must thread the returned value back in as prevDelay on the next
+// call. Returns the delay to wait before the next attempt.
+func exponentialBackoffWithJitter(cfg RetryConfig, attempt int, retryAfter time.Duration, prevDelay time.Duration) time.Duration {
+	// If Retry-After header is present and we respect it, use it, capped at
+	// RetryAfterMax. RetryAfterMax == 0 opts out of honoring the header
+	// entirely, regardless of RespectRetryAfter.
+	if retryAfter > 0 && cfg.RespectRetryAfter && cfg.RetryAfterMax > 0 {
+		if retryAfter > cfg.RetryAfterMax {
+			return cfg.RetryAfterMax
+		}
 		return retryAfter
 	}
 
+	initialDelay := cfg.BaseBackoff
+	if initialDelay <= 0 {
+		initialDelay = cfg.InitialDelay
+	}
+	maxDelay := cfg.MaxBackoff
+	if maxDelay <= 0 {
+		maxDelay = cfg.MaxDelay
+	}
+
+	if cfg.JitterMode == JitterDecorrelated {
+		return decorrelatedJitterDelay(initialDelay, maxDelay, prevDelay)
+	}
+	if cfg.JitterMode == JitterConstant {
+		return initialDelay
+	}
+
 	// Calculate base delay with exponential backoff
-	base := cfg.InitialDelay
+	base := initialDelay
 	if attempt > 0 {
-		base = time.Duration(float64(cfg.InitialDelay) * math.Pow(cfg.Multiplier, float64(attempt)))
+		base = time.Duration(float64(initialDelay) * math.Pow(cfg.Multiplier, float64(attempt)))
 	}
 
 	// Cap at max delay
-	if base > cfg.MaxDelay {
-		base = cfg.MaxDelay
+	if base > maxDelay {
+		base = maxDelay
 	}
 
-	// Apply full jitter: random value between 0 and base
 	maxNanos := base.Nanoseconds()
 	if maxNanos <= 0 {
 		return 0
 	}
 
-	jitteredNanos := rand.Int63n(maxNanos + 1)
-	return time.Duration(jitteredNanos)
+	switch cfg.JitterMode {
+	case JitterNone:
+		return base
+	case JitterEqual:
+		half := maxNanos / 2
+		return time.Duration(half + rand.Int63n(maxNanos-half+1))
+	default: // JitterFull
+		return time.Duration(rand.Int63n(maxNanos + 1))
+	}
+}
+
+// decorrelatedJitterDelay implements AWS's "decorrelated jitter" backoff:
+// sleep = min(cap, random_between(base, prevDelay*3)). Seeding prevDelay
+// with base on the first call keeps the first retry close to the
+// unjittered base delay instead of spiking to the cap.
+func decorrelatedJitterDelay(base, maxDelay, prevDelay time.Duration) time.Duration {
+	if prevDelay <= 0 {
+		prevDelay = base
+	}
+
+	upper := prevDelay * 3
+	if upper < base {
+		upper = base
+	}
+
+	span := (upper - base).Nanoseconds()
+	delay := base
+	if span > 0 {
+		delay = base + time.Duration(rand.Int63n(span+1))
+	}
+
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// ensureRetryDeadline returns ctx unchanged if it already carries a
+// deadline, so a caller-supplied deadline or cancellation is always what
+// ultimately bounds a retry loop's waiting. Otherwise it derives one from
+// cfg.TotalWaitCap via context.WithTimeout, so TotalWaitCap still acts as a
+// fallback budget for callers that pass context.Background() instead of a
+// context with its own deadline. The returned cancel must be deferred by the
+// caller to release the timer either way.
+func ensureRetryDeadline(ctx context.Context, cfg RetryConfig) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, cfg.TotalWaitCap)
+}
+
+// clampToDeadline reports whether ctx's deadline (if any) still leaves time
+// for another backoff sleep of backoffDelay: ok is false once the deadline
+// has already passed, in which case the caller should give up rather than
+// attempt another clamped sleep of zero or negative duration. When ok is
+// true, the returned duration is backoffDelay clamped to whatever time
+// remains before the deadline.
+func clampToDeadline(ctx context.Context, backoffDelay time.Duration) (time.Duration, bool) {
+	deadline, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		return backoffDelay, true
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, false
+	}
+	if backoffDelay > remaining {
+		return remaining, true
+	}
+	return backoffDelay, true
 }
 
 // isRateLimitError checks if an error is a rate limit error
@@ -140,15 +361,122 @@ func isRateLimitError(err error) (*RateLimitError, bool) {
 	return nil, false
 }
 
-// makeRequestWithRetry executes an HTTP request with retry logic for rate limits
+// isRetryableStatus reports whether statusCode matches one of cfg's retryable
+// patterns, falling back to defaultRetryableStatusCodes when none is
+// configured.
+func isRetryableStatus(cfg RetryConfig, statusCode int) bool {
+	codes := cfg.RetryStatusCodes
+	if len(codes) == 0 {
+		codes = defaultRetryableStatusCodes
+	}
+	for _, pattern := range codes {
+		if statusCodeMatches(pattern, statusCode) {
+			return true
+		}
+	}
+	return false
+}
+
+// statusCodeMatches reports whether pattern matches statusCode: pattern is
+// either an exact status code ("502") or a wildcard class ("5xx"/"5XX",
+// matching every status in that hundred). An unparseable pattern never
+// matches.
+func statusCodeMatches(pattern string, statusCode int) bool {
+	if len(pattern) == 3 && (pattern[1] == 'x' || pattern[1] == 'X') && (pattern[2] == 'x' || pattern[2] == 'X') {
+		class, err := strconv.Atoi(pattern[:1])
+		return err == nil && statusCode/100 == class
+	}
+
+	code, err := strconv.Atoi(pattern)
+	return err == nil && code == statusCode
+}
+
+// awaitRateLimit consults the client's proactive RateLimitMap for url's
+// category before an attempt is made. If the category isn't cooling down it
+// returns immediately; otherwise it either waits out the cooldown (capped by
+// cfg.TotalWaitCap) or, if cfg.SkipIfRateLimited is set, fails fast with an
+// *ErrRateLimited without consuming a retry attempt.
+func (c *Client) awaitRateLimit(ctx context.Context, cfg RetryConfig, url string) error {
+	category := categoryForURL(url)
+	deadline := c.rateLimits.deadline(category)
+	now := c.clock.Now()
+	if deadline.IsZero() || !now.Before(deadline) {
+		return nil
+	}
+
+	wait := deadline.Sub(now)
+	if cfg.SkipIfRateLimited {
+		return &ErrRateLimited{Category: category, RetryAfter: wait}
+	}
+	if wait > cfg.TotalWaitCap {
+		wait = cfg.TotalWaitCap
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request canceled while waiting out rate limit: %w", ctx.Err())
+	case <-c.clock.After(wait):
+		return nil
+	}
+}
+
+// classifyRetryable decides whether a failed attempt should be retried. It
+// honors cfg.ShouldRetry when set, otherwise falls back in order to: rate
+// limits, BusinessMap's typed *APIError (whose Retryable() overrides the
+// generic status-code classification — e.g. a 409 "conflict" retries even
+// though 409 isn't itself in the default retryable status list, while a 401
+// or 404 stops after one attempt regardless of status), configured
+// retryable status codes, and transient net.Errors (timeouts, connection
+// resets) that never produced an HTTP response at all.
+func classifyRetryable(cfg RetryConfig, resp *http.Response, err error) bool {
+	if cfg.ShouldRetry != nil {
+		return cfg.ShouldRetry(resp, err)
+	}
+
+	if _, ok := isRateLimitError(err); ok {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return isRetryableStatus(cfg, statusErr.StatusCode)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return false
+}
+
+// makeRequestWithRetry is the shared general-purpose retry transport: it
+// retries 429/502/503/504 and transient network errors with exponential
+// backoff plus jitter, and short-circuits through a per-host circuit breaker
+// so a dead BusinessMap instance doesn't eat the whole retry budget on every
+// call. ReadCard and AddCardComment flow through it; GetCardsWithRetry and
+// GetCardsStream use fetchWithRetry, which runs its own loop with the same
+// classification, backoff, and breaker.
 func (c *Client) makeRequestWithRetry(ctx context.Context, cfg RetryConfig, method, url string, body interface{}) ([]byte, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid retry config: %w", err)
 	}
 
+	ctx, cancel := ensureRetryDeadline(ctx, cfg)
+	defer cancel()
+
+	breaker, host := c.circuitBreakerFor(url)
+	observer := cfg.observerFor()
+
 	var lastErr error
 	totalWaitTime := time.Duration(0)
-	startTime := time.Now()
+	var prevDelay time.Duration
+	startTime := c.clock.Now()
 
 	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
 		// Check context cancellation
@@ -158,54 +486,133 @@ func (c *Client) makeRequestWithRetry(ctx context.Context, cfg RetryConfig, meth
 		default:
 		}
 
+		if err := c.awaitRateLimit(ctx, cfg, url); err != nil {
+			return nil, err
+		}
+
+		if allowed, coolsDown := breaker.allow(); !allowed {
+			c.metrics.observeCircuitState(host, breaker.currentState())
+			return nil, &CircuitOpenError{Host: host, CoolsDown: coolsDown}
+		}
+
 		// Attempt the request
-		result, err := c.makeAPIRequestWithBody(method, url, body)
+		attemptStart := c.clock.Now()
+		result, resp, err := c.doRequest(ctx, method, url, body, attempt)
+		c.recordRateLimitResponse(url, resp)
+
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		rateLimitCategory := ""
+		if _, ok := isRateLimitError(err); ok {
+			rateLimitCategory = categoryForURL(url)
+		}
+		observer.OnAttempt(ctx, RetryEvent{
+			URL:               url,
+			Attempt:           attempt + 1,
+			MaxAttempts:       cfg.MaxAttempts,
+			StatusCode:        statusCode,
+			Duration:          c.clock.Now().Sub(attemptStart),
+			Err:               err,
+			RateLimitCategory: rateLimitCategory,
+		})
+		rec := AttemptRecord{
+			Attempt:    attempt + 1,
+			StartTime:  attemptStart,
+			Duration:   c.clock.Now().Sub(attemptStart),
+			StatusCode: statusCode,
+			Err:        err,
+		}
+
 		if err == nil {
+			breaker.recordSuccess()
+			c.metrics.observeCircuitState(host, breaker.currentState())
+			c.retryTokens.creditSuccess(c.retryTokenSuccessRate)
+			if c.onRetry != nil {
+				c.onRetry(rec)
+			}
 			if attempt > 0 {
-				log.Printf("[RETRY] Success after %d attempts, total wait: %v", attempt+1, totalWaitTime)
+				observer.OnSuccess(ctx, attempt+1, totalWaitTime)
 			}
 			return result, nil
 		}
 
-		// Check if it's a rate limit error
-		rateLimitErr, isRateLimit := isRateLimitError(err)
-		if !isRateLimit {
-			// Non-rate-limit error, fail fast
+		if !classifyRetryable(cfg, resp, err) {
+			// Not retryable, fail fast; the circuit breaker only tracks
+			// failures that retrying could plausibly fix.
+			if c.onRetry != nil {
+				c.onRetry(rec)
+			}
 			return nil, err
 		}
 
+		breaker.recordFailure()
+		c.metrics.observeCircuitState(host, breaker.currentState())
 		lastErr = err
 
 		// Check if we've exhausted attempts
 		if attempt >= cfg.MaxAttempts-1 {
-			log.Printf("[RETRY] Max attempts (%d) exceeded for %s", cfg.MaxAttempts, url)
-			return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
+			giveUpErr := fmt.Errorf("max retries exceeded: %w", lastErr)
+			if c.onRetry != nil {
+				c.onRetry(rec)
+			}
+			observer.OnGiveUp(ctx, giveUpErr)
+			return nil, giveUpErr
 		}
 
-		// Calculate backoff delay
-		backoffDelay := exponentialBackoffWithJitter(cfg, attempt, rateLimitErr.RetryAfter)
+		c.metrics.observeRetryAttempt(normalizeEndpoint(url))
 
-		// Check if waiting would exceed total wait cap
-		if totalWaitTime+backoffDelay > cfg.TotalWaitCap {
-			log.Printf("[RETRY] Would exceed total wait cap (%v), aborting", cfg.TotalWaitCap)
-			return nil, fmt.Errorf("total wait time would exceed cap (%v): %w", cfg.TotalWaitCap, lastErr)
+		var retryAfter time.Duration
+		isRateLimit := false
+		if rateLimitErr, ok := isRateLimitError(err); ok {
+			retryAfter = rateLimitErr.RetryAfter
+			isRateLimit = true
 		}
 
-		// Log retry attempt
-		if rateLimitErr.RetryAfter > 0 {
-			log.Printf("[RETRY] Attempt %d/%d failed: rate limit hit (Retry-After: %v), waiting %v",
-				attempt+1, cfg.MaxAttempts, rateLimitErr.RetryAfter, backoffDelay)
-		} else {
-			log.Printf("[RETRY] Attempt %d/%d failed: rate limit hit, waiting %v",
-				attempt+1, cfg.MaxAttempts, backoffDelay)
+		// Calculate backoff delay, then clamp it to however much of the
+		// context deadline (real, or TotalWaitCap-derived) remains.
+		backoffDelay := exponentialBackoffWithJitter(cfg, attempt, retryAfter, prevDelay)
+		prevDelay = backoffDelay
+
+		clamped, ok := clampToDeadline(ctx, backoffDelay)
+		if !ok {
+			ctxErr := ctx.Err()
+			if ctxErr == nil {
+				ctxErr = context.DeadlineExceeded
+			}
+			giveUpErr := fmt.Errorf("%w (last attempt failed with %v)", ctxErr, lastErr)
+			if c.onRetry != nil {
+				c.onRetry(rec)
+			}
+			observer.OnGiveUp(ctx, giveUpErr)
+			return nil, giveUpErr
+		}
+		backoffDelay = clamped
+
+		if !c.retryTokens.acquire() {
+			giveUpErr := fmt.Errorf("retry budget exhausted: %w", lastErr)
+			if c.onRetry != nil {
+				c.onRetry(rec)
+			}
+			observer.OnGiveUp(ctx, giveUpErr)
+			return nil, giveUpErr
 		}
 
+		rec.RetryAfterUsed = isRateLimit && retryAfter > 0
+		rec.SleepBeforeNext = backoffDelay
+		if c.onRetry != nil {
+			c.onRetry(rec)
+		}
+
+		observer.OnBackoff(ctx, backoffDelay, fmt.Sprintf("attempt %d/%d failed for %s (%v)", attempt+1, cfg.MaxAttempts, url, err))
+
 		// Wait with context awareness
 		select {
 		case <-ctx.Done():
 			return nil, fmt.Errorf("request canceled during backoff: %w", ctx.Err())
-		case <-time.After(backoffDelay):
-			totalWaitTime = time.Since(startTime)
+		case <-c.clock.After(backoffDelay):
+			totalWaitTime = c.clock.Now().Sub(startTime)
 		}
 	}
 
@@ -225,6 +632,32 @@ func enhanceErrorWithRateLimit(resp *http.Response, originalErr error, body []by
 	return originalErr
 }
 
+// RetryTerminationReason classifies why a retry loop stopped, so an LLM
+// caller can decide whether retrying again (with a fresh deadline/token) is
+// worthwhile.
+type RetryTerminationReason string
+
+const (
+	ReasonSuccess            RetryTerminationReason = "success"
+	ReasonDeadlineExceeded   RetryTerminationReason = "deadline"
+	ReasonCanceled           RetryTerminationReason = "canceled"
+	ReasonAttemptsExhausted  RetryTerminationReason = "attempts_exhausted"
+	ReasonTotalWaitCapHit    RetryTerminationReason = "total_wait_cap"
+	ReasonCircuitOpen        RetryTerminationReason = "circuit_open"
+	ReasonRateLimited        RetryTerminationReason = "rate_limited"
+	ReasonNonRetryableStatus RetryTerminationReason = "non_retryable_status"
+	ReasonBudgetExhausted    RetryTerminationReason = "shared_budget_exhausted"
+)
+
+// reasonForContextErr distinguishes an absolute deadline firing from an
+// explicit cancellation (e.g. via the cancel_retry tool), based on ctx.Err().
+func reasonForContextErr(err error) RetryTerminationReason {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ReasonDeadlineExceeded
+	}
+	return ReasonCanceled
+}
+
 // endpointResult tracks the result of fetching a single endpoint
 type endpointResult struct {
 	name          string
@@ -233,11 +666,103 @@ type endpointResult struct {
 	rateLimitHits int
 	success       bool
 	err           error
+	reason        RetryTerminationReason
+	trace         []AttemptRecord
+}
+
+// EndpointSpec names one endpoint in a Client.FetchGroup fan-out: the URL to
+// fetch, a Parse callback invoked with the response body on success, and
+// whether a failure (fetch or parse) on this endpoint should fail the whole
+// group. Non-required endpoints that fail are still reported in FetchGroup's
+// results map; they just don't turn into a group-level error.
+type EndpointSpec struct {
+	Name     string
+	URL      string
+	Parse    func(data []byte) error
+	Required bool
+}
+
+// FetchGroup fans out one concurrent GET per spec, sharing a single
+// TotalWaitCap budget across all of them via a sharedBudget (so one slow
+// endpoint can't spend the whole group's wait budget alone) and the Client's
+// single RateLimitMap, so a 429 on one endpoint immediately pauses its
+// peers instead of each independently discovering and waiting out the same
+// limit. cfg.MaxConcurrency bounds how many specs may have a request in
+// flight at once, which matters when a caller fans out many groups at once
+// (e.g. reading 50 cards) and must not open hundreds of sockets.
+//
+// The specs also share one breakerFailureGuard, so the group's own internal
+// retries only ever count once against a host's circuit breaker no matter
+// how many of its concurrent sub-fetches fail - see breakerFailureGuard.
+//
+// FetchGroup always returns the results map, even when it also returns an
+// error, so callers can inspect what did complete. A Required spec's fetch
+// or parse failure is returned as the group error; a non-required spec's
+// failure is only visible via its endpointResult in the map.
+func (c *Client) FetchGroup(ctx context.Context, cfg RetryConfig, specs []EndpointSpec) (map[string]*endpointResult, error) {
+	budget := newSharedBudget(cfg.TotalWaitCap)
+	maxConcurrency := cfg.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = len(specs)
+	}
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+	sem := make(chan struct{}, maxConcurrency)
+	guard := newBreakerFailureGuard()
+
+	type fetchResult struct {
+		name   string
+		result *endpointResult
+	}
+
+	resultsChan := make(chan fetchResult, len(specs))
+	for _, spec := range specs {
+		spec := spec
+		go func() {
+			result := c.fetchWithRetryShared(ctx, cfg, spec.Name, spec.URL, budget, sem, guard)
+			resultsChan <- fetchResult{name: spec.Name, result: result}
+		}()
+	}
+
+	results := make(map[string]*endpointResult, len(specs))
+	for i := 0; i < len(specs); i++ {
+		select {
+		case <-ctx.Done():
+			return results, fmt.Errorf("context canceled: %w", ctx.Err())
+		case fr := <-resultsChan:
+			results[fr.name] = fr.result
+		}
+	}
+
+	for _, spec := range specs {
+		result := results[spec.Name]
+		if !result.success {
+			if spec.Required {
+				return results, fmt.Errorf("failed to fetch %s: %w", spec.Name, result.err)
+			}
+			continue
+		}
+		if spec.Parse == nil {
+			continue
+		}
+		if err := spec.Parse(result.data); err != nil && spec.Required {
+			return results, fmt.Errorf("failed to parse %s: %w", spec.Name, err)
+		}
+	}
+
+	return results, nil
 }
 
 // ReadCardWithRetry fetches card data with retry logic for rate limiting
-// It returns a structured response with metadata about retry attempts
-func (c *Client) ReadCardWithRetry(ctx context.Context, cardIDOrURL string, cfg RetryConfig, failOnPartial bool) (*ReadCardWithRetryResponse, error) {
+// It returns a structured response with metadata about retry attempts. opts
+// may override per-call behavior such as the API key, base URL, or retry
+// budget without affecting the Client's other callers; see the option
+// package.
+func (c *Client) ReadCardWithRetry(ctx context.Context, cardIDOrURL string, cfg RetryConfig, failOnPartial bool, opts ...RequestOption) (*ReadCardWithRetryResponse, error) {
+	ctx = withRequestOptions(ctx, opts...)
+	cfg = retryConfigFor(ctx, cfg)
+
 	cardID, err := c.extractCardID(cardIDOrURL)
 	if err != nil {
 		return nil, err
@@ -247,142 +772,166 @@ func (c *Client) ReadCardWithRetry(ctx context.Context, cardIDOrURL string, cfg
 		return nil, fmt.Errorf("invalid retry config: %w", err)
 	}
 
-	startTime := time.Now()
+	startTime := c.clock.Now()
+	ctx, endCallSpan := c.startCallSpan(ctx, "kanbanize.read_card_with_retry")
 	response := &ReadCardWithRetryResponse{
 		CardID:       cardID,
 		Attempts:     make(map[string]int),
 		Completed:    make(map[string]bool),
 		PartialError: make(map[string]string),
+		Reasons:      make(map[string]string),
 		Data:         &ReadCardResponse{},
 	}
 
-	// Fetch primary card data (required)
-	cardResult := c.fetchWithRetry(ctx, cfg, "card", fmt.Sprintf("%s/api/v2/cards/%s", c.baseURL, cardID))
-	response.Attempts["card"] = cardResult.attempts
-	response.RateLimitHits += cardResult.rateLimitHits
-	response.Completed["card"] = cardResult.success
+	var cardDataResp CardDataResponse
+	var commentsResp CommentsResponse
+	var subtasksResp SubtasksResponse
 
-	if !cardResult.success {
-		response.PartialError["card"] = cardResult.err.Error()
-		response.WaitSeconds = time.Since(startTime).Seconds()
-		return response, fmt.Errorf("failed to fetch card: %w", cardResult.err)
+	specs := []EndpointSpec{
+		{
+			Name:     "card",
+			URL:      fmt.Sprintf("%s/api/v2/cards/%s", c.baseURLFor(ctx), cardID),
+			Parse:    func(data []byte) error { return json.Unmarshal(data, &cardDataResp) },
+			Required: true,
+		},
+		{
+			Name:     "comments",
+			URL:      fmt.Sprintf("%s/api/v2/cards/%s/comments", c.baseURLFor(ctx), cardID),
+			Parse:    func(data []byte) error { return json.Unmarshal(data, &commentsResp) },
+			Required: failOnPartial,
+		},
+		{
+			Name:     "subtasks",
+			URL:      fmt.Sprintf("%s/api/v2/cards/%s/subtasks", c.baseURLFor(ctx), cardID),
+			Parse:    func(data []byte) error { return json.Unmarshal(data, &subtasksResp) },
+			Required: failOnPartial,
+		},
 	}
 
-	// Parse card data
-	var cardDataResp CardDataResponse
-	if err := json.Unmarshal(cardResult.data, &cardDataResp); err != nil {
-		return response, fmt.Errorf("failed to parse card data: %w", err)
+	results, groupErr := c.FetchGroup(ctx, cfg, specs)
+	for name, result := range results {
+		response.Attempts[name] = result.attempts
+		response.Completed[name] = result.success
+		response.Reasons[name] = string(result.reason)
+		if !result.success {
+			response.PartialError[name] = result.err.Error()
+		}
+	}
+	// Only the required spec(s) contribute to RateLimitHits: it reports how
+	// often the data this call can't do without got rate limited, not every
+	// rate limit hit a best-effort secondary endpoint (comments/subtasks)
+	// happened to absorb on its own independent retries.
+	for _, spec := range specs {
+		if spec.Required {
+			response.RateLimitHits += results[spec.Name].rateLimitHits
+		}
+	}
+	// Walk specs rather than results directly so RetryTrace has a
+	// deterministic order (card, comments, subtasks) instead of the
+	// map-iteration order FetchGroup's concurrent fetches complete in.
+	for _, spec := range specs {
+		if result, ok := results[spec.Name]; ok {
+			response.RetryTrace = append(response.RetryTrace, result.trace...)
+		}
+	}
+	// Populate whatever specs succeeded before checking groupErr, so a
+	// caller gets the partial ReadCardResponse documented for
+	// failOnPartial=false (and for failOnPartial=true, the partial data
+	// returned alongside the error) instead of an empty one whenever only a
+	// secondary endpoint (comments/subtasks) failed.
+	popErr := c.populateReadCardData(response, &cardDataResp, &commentsResp, &subtasksResp, results)
+
+	if groupErr != nil {
+		response.WaitSeconds = c.clock.Now().Sub(startTime).Seconds()
+		endCallSpan(groupErr)
+		return response, groupErr
+	}
+	if popErr != nil {
+		response.WaitSeconds = c.clock.Now().Sub(startTime).Seconds()
+		endCallSpan(popErr)
+		return response, popErr
+	}
+
+	response.WaitSeconds = c.clock.Now().Sub(startTime).Seconds()
+	endCallSpan(nil)
+	return response, nil
+}
+
+// populateReadCardData copies whatever of cardDataResp/commentsResp/
+// subtasksResp actually succeeded (per results) onto response.Data. The card
+// fields are only populated if the "card" spec succeeded; comments and
+// subtasks are each populated independently of one another and of the card
+// result, so a partial failure on one secondary endpoint doesn't blank out
+// data already fetched from the others.
+func (c *Client) populateReadCardData(response *ReadCardWithRetryResponse, cardDataResp *CardDataResponse, commentsResp *CommentsResponse, subtasksResp *SubtasksResponse, results map[string]*endpointResult) error {
+	if card, ok := results["card"]; !ok || !card.success {
+		return nil
 	}
 
-	// Populate basic card fields
 	response.Data.Title = cardDataResp.Data.Title
 	response.Data.Description = cardDataResp.Data.Description
 	response.Data.LinkedCards = cardDataResp.Data.LinkedCards
 	response.Data.CustomFields = cardDataResp.Data.CustomFields
-	response.Data.CreatedAt = parseTimestamp(cardDataResp.Data.CreatedAt)
-	response.Data.LastModified = parseTimestamp(cardDataResp.Data.LastModified)
-	response.Data.InCurrentPositionSince = parseTimestamp(cardDataResp.Data.InCurrentPositionSince)
-	response.Data.FirstRequestTime = parseTimestamp(cardDataResp.Data.FirstRequestTime)
-	response.Data.FirstStartTime = parseTimestamp(cardDataResp.Data.FirstStartTime)
-	response.Data.FirstEndTime = parseTimestamp(cardDataResp.Data.FirstEndTime)
-	response.Data.LastRequestTime = parseTimestamp(cardDataResp.Data.LastRequestTime)
-	response.Data.LastStartTime = parseTimestamp(cardDataResp.Data.LastStartTime)
-	response.Data.LastEndTime = parseTimestamp(cardDataResp.Data.LastEndTime)
 
 	// Parse initiative details if present
 	if cardDataResp.Data.InitiativeDetails != nil {
 		response.Data.PlannedStartDate = cardDataResp.Data.InitiativeDetails.PlannedStartDate
 		response.Data.PlannedEndDate = cardDataResp.Data.InitiativeDetails.PlannedEndDate
-		response.Data.ActualStartTime = parseTimestamp(cardDataResp.Data.InitiativeDetails.ActualStartTime)
-		response.Data.ActualEndTime = parseTimestamp(cardDataResp.Data.InitiativeDetails.ActualEndTime)
 	}
 
-	// Fetch comments and subtasks in parallel (secondary endpoints)
-	type fetchResult struct {
-		name   string
-		result *endpointResult
-		data   []byte
+	if err := c.populateTimestamps(response.Data, cardTimestampFields(&cardDataResp.Data, response.Data)); err != nil {
+		return err
 	}
 
-	resultsChan := make(chan fetchResult, 2)
-
-	// Fetch comments
-	go func() {
-		result := c.fetchWithRetry(ctx, cfg, "comments", fmt.Sprintf("%s/api/v2/cards/%s/comments", c.baseURL, cardID))
-		resultsChan <- fetchResult{name: "comments", result: result, data: result.data}
-	}()
-
-	// Fetch subtasks
-	go func() {
-		result := c.fetchWithRetry(ctx, cfg, "subtasks", fmt.Sprintf("%s/api/v2/cards/%s/subtasks", c.baseURL, cardID))
-		resultsChan <- fetchResult{name: "subtasks", result: result, data: result.data}
-	}()
+	if comments, ok := results["comments"]; ok && comments.success {
+		parsed := make([]Comment, len(commentsResp.Data))
+		for i, commentData := range commentsResp.Data {
+			parsed[i] = Comment{
+				ID:        strconv.Itoa(commentData.CommentID),
+				Text:      commentData.Text,
+				Author:    commentData.AuthorName,
+				CreatedAt: c.parseCommentTimestamp(commentData.CreatedAt),
+			}
+		}
+		response.Data.Comments = parsed
+	}
 
-	// Collect results
-	for i := 0; i < 2; i++ {
-		select {
-		case <-ctx.Done():
-			return response, fmt.Errorf("context canceled: %w", ctx.Err())
-		case result := <-resultsChan:
-			response.Attempts[result.name] = result.result.attempts
-			response.RateLimitHits += result.result.rateLimitHits
-			response.Completed[result.name] = result.result.success
-
-			if !result.result.success {
-				response.PartialError[result.name] = result.result.err.Error()
-				if failOnPartial {
-					response.WaitSeconds = time.Since(startTime).Seconds()
-					return response, fmt.Errorf("failed to fetch %s: %w", result.name, result.result.err)
-				}
-			} else {
-				// Parse successful results
-				if result.name == "comments" {
-					var commentsResp CommentsResponse
-					if err := json.Unmarshal(result.data, &commentsResp); err == nil {
-						comments := make([]Comment, len(commentsResp.Data))
-						for i, commentData := range commentsResp.Data {
-							comments[i] = Comment{
-								ID:        strconv.Itoa(commentData.CommentID),
-								Text:      commentData.Text,
-								Author:    commentData.AuthorName,
-								CreatedAt: parseCommentTimestamp(commentData.CreatedAt),
-							}
-						}
-						response.Data.Comments = comments
-					}
-				} else if result.name == "subtasks" {
-					var subtasksResp SubtasksResponse
-					if err := json.Unmarshal(result.data, &subtasksResp); err == nil {
-						subtasks := make([]Subtask, len(subtasksResp.Data))
-						for i, subtaskData := range subtasksResp.Data {
-							subtasks[i] = Subtask{
-								ID:          strconv.Itoa(subtaskData.SubtaskID),
-								Title:       subtaskData.Title,
-								Description: subtaskData.Description,
-								Completed:   subtaskData.Finished == 1,
-							}
-						}
-						response.Data.Subtasks = subtasks
-					}
-				}
+	if subtasks, ok := results["subtasks"]; ok && subtasks.success {
+		parsed := make([]Subtask, len(subtasksResp.Data))
+		for i, subtaskData := range subtasksResp.Data {
+			parsed[i] = Subtask{
+				ID:          strconv.Itoa(subtaskData.SubtaskID),
+				Title:       subtaskData.Title,
+				Description: subtaskData.Description,
+				Completed:   subtaskData.Finished == 1,
 			}
 		}
+		response.Data.Subtasks = parsed
 	}
 
-	response.WaitSeconds = time.Since(startTime).Seconds()
-	return response, nil
+	return nil
 }
 
-// fetchWithRetry is a helper that wraps makeRequestWithRetry with result tracking
+// fetchWithRetry is a GET retry loop of its own, separate from
+// makeRequestWithRetry but applying the same retry policy, with
+// per-endpoint result tracking (attempts, rate-limit hits) and circuit
+// breaker short-circuiting.
 func (c *Client) fetchWithRetry(ctx context.Context, cfg RetryConfig, name, url string) *endpointResult {
 	result := &endpointResult{
 		name:     name,
 		attempts: 0,
 	}
 
+	ctx, cancel := ensureRetryDeadline(ctx, cfg)
+	defer cancel()
+
+	breaker, host := c.circuitBreakerFor(url)
+	observer := cfg.observerFor()
+
 	var lastErr error
 	totalWaitTime := time.Duration(0)
-	startTime := time.Now()
+	var prevDelay time.Duration
+	startTime := c.clock.Now()
 
 	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
 		result.attempts = attempt + 1
@@ -391,71 +940,159 @@ func (c *Client) fetchWithRetry(ctx context.Context, cfg RetryConfig, name, url
 		select {
 		case <-ctx.Done():
 			result.err = fmt.Errorf("request canceled: %w", ctx.Err())
+			result.reason = reasonForContextErr(ctx.Err())
 			return result
 		default:
 		}
 
+		if err := c.awaitRateLimit(ctx, cfg, url); err != nil {
+			if rlErr, ok := err.(*ErrRateLimited); ok {
+				// Skipped before ever reaching the network; don't count it
+				// as a consumed attempt.
+				result.attempts = attempt
+				result.err = rlErr
+				result.reason = ReasonRateLimited
+				return result
+			}
+			result.err = err
+			result.reason = reasonForContextErr(ctx.Err())
+			return result
+		}
+
+		if allowed, coolsDown := breaker.allow(); !allowed {
+			c.metrics.observeCircuitState(host, breaker.currentState())
+			result.err = &CircuitOpenError{Host: host, CoolsDown: coolsDown}
+			result.reason = ReasonCircuitOpen
+			return result
+		}
+
 		// Attempt the request
-		data, err := c.makeAPIRequest(url)
+		attemptStart := c.clock.Now()
+		data, resp, err := c.doRequest(ctx, "GET", url, nil, attempt)
+		c.recordRateLimitResponse(url, resp)
+
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		rateLimitCategory := ""
+		if _, ok := isRateLimitError(err); ok {
+			rateLimitCategory = categoryForURL(url)
+		}
+		observer.OnAttempt(ctx, RetryEvent{
+			Name:              name,
+			URL:               url,
+			Attempt:           attempt + 1,
+			MaxAttempts:       cfg.MaxAttempts,
+			StatusCode:        statusCode,
+			Duration:          c.clock.Now().Sub(attemptStart),
+			Err:               err,
+			RateLimitCategory: rateLimitCategory,
+		})
+		rec := AttemptRecord{
+			Name:       name,
+			Attempt:    attempt + 1,
+			StartTime:  attemptStart,
+			Duration:   c.clock.Now().Sub(attemptStart),
+			StatusCode: statusCode,
+			Err:        err,
+		}
+
 		if err == nil {
+			breaker.recordSuccess()
+			c.metrics.observeCircuitState(host, breaker.currentState())
+			c.retryTokens.creditSuccess(c.retryTokenSuccessRate)
 			result.success = true
 			result.data = data
+			result.reason = ReasonSuccess
+			c.recordAttempt(&result.trace, rec)
 			if attempt > 0 {
-				log.Printf("[RETRY] Success for %s after %d attempts, total wait: %v", name, attempt+1, totalWaitTime)
+				observer.OnSuccess(ctx, attempt+1, totalWaitTime)
 			}
 			return result
 		}
 
-		// Check if it's a rate limit error
 		rateLimitErr, isRateLimit := isRateLimitError(err)
 		if isRateLimit {
 			result.rateLimitHits++
 		}
 
-		if !isRateLimit {
-			// Non-rate-limit error, fail fast
+		if !classifyRetryable(cfg, resp, err) {
+			// Not retryable, fail fast; only retryable failures count
+			// against the circuit breaker.
 			result.err = err
+			result.reason = ReasonNonRetryableStatus
+			c.recordAttempt(&result.trace, rec)
 			return result
 		}
 
+		breaker.recordFailure()
+		c.metrics.observeCircuitState(host, breaker.currentState())
 		lastErr = err
 
 		// Check if we've exhausted attempts
 		if attempt >= cfg.MaxAttempts-1 {
-			log.Printf("[RETRY] Max attempts (%d) exceeded for %s", cfg.MaxAttempts, name)
 			result.err = fmt.Errorf("max retries exceeded: %w", lastErr)
+			result.reason = ReasonAttemptsExhausted
+			c.recordAttempt(&result.trace, rec)
+			observer.OnGiveUp(ctx, result.err)
 			return result
 		}
 
-		// Calculate backoff delay
-		backoffDelay := exponentialBackoffWithJitter(cfg, attempt, rateLimitErr.RetryAfter)
+		c.metrics.observeRetryAttempt(normalizeEndpoint(url))
+
+		var retryAfter time.Duration
+		if isRateLimit {
+			retryAfter = rateLimitErr.RetryAfter
+		}
+
+		// Calculate backoff delay, then clamp it to however much of the
+		// context deadline (real, or TotalWaitCap-derived) remains.
+		backoffDelay := exponentialBackoffWithJitter(cfg, attempt, retryAfter, prevDelay)
+		prevDelay = backoffDelay
 
-		// Check if waiting would exceed total wait cap
-		if totalWaitTime+backoffDelay > cfg.TotalWaitCap {
-			log.Printf("[RETRY] Would exceed total wait cap (%v) for %s, aborting", cfg.TotalWaitCap, name)
-			result.err = fmt.Errorf("total wait time would exceed cap (%v): %w", cfg.TotalWaitCap, lastErr)
+		clamped, ok := clampToDeadline(ctx, backoffDelay)
+		if !ok {
+			ctxErr := ctx.Err()
+			if ctxErr == nil {
+				ctxErr = context.DeadlineExceeded
+			}
+			result.err = fmt.Errorf("%w (last attempt failed with %v)", ctxErr, lastErr)
+			result.reason = reasonForContextErr(ctxErr)
+			c.recordAttempt(&result.trace, rec)
+			observer.OnGiveUp(ctx, result.err)
 			return result
 		}
+		backoffDelay = clamped
 
-		// Log retry attempt
-		if rateLimitErr.RetryAfter > 0 {
-			log.Printf("[RETRY] %s attempt %d/%d failed: rate limit hit (Retry-After: %v), waiting %v",
-				name, attempt+1, cfg.MaxAttempts, rateLimitErr.RetryAfter, backoffDelay)
-		} else {
-			log.Printf("[RETRY] %s attempt %d/%d failed: rate limit hit, waiting %v",
-				name, attempt+1, cfg.MaxAttempts, backoffDelay)
+		if !c.retryTokens.acquire() {
+			result.err = fmt.Errorf("retry budget exhausted: %w", lastErr)
+			result.reason = ReasonAttemptsExhausted
+			c.recordAttempt(&result.trace, rec)
+			observer.OnGiveUp(ctx, result.err)
+			return result
 		}
 
-		// Wait with context awareness
+		rec.RetryAfterUsed = isRateLimit && retryAfter > 0
+		rec.SleepBeforeNext = backoffDelay
+		c.recordAttempt(&result.trace, rec)
+
+		observer.OnBackoff(ctx, backoffDelay, fmt.Sprintf("%s attempt %d/%d failed (%v)", name, attempt+1, cfg.MaxAttempts, err))
+
+		// Wait with context awareness, so a caller-supplied deadline or
+		// cancel_token wakes the sleep immediately instead of blocking for
+		// the full backoff.
 		select {
 		case <-ctx.Done():
 			result.err = fmt.Errorf("request canceled during backoff: %w", ctx.Err())
+			result.reason = reasonForContextErr(ctx.Err())
 			return result
-		case <-time.After(backoffDelay):
-			totalWaitTime = time.Since(startTime)
+		case <-c.clock.After(backoffDelay):
+			totalWaitTime = c.clock.Now().Sub(startTime)
 		}
 	}
 
 	result.err = fmt.Errorf("max retries exceeded: %w", lastErr)
+	result.reason = ReasonAttemptsExhausted
 	return result
 }
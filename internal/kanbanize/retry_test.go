@@ -19,9 +19,13 @@ package kanbanize
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -117,6 +121,18 @@ func TestRetryConfigValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "invalid jitter mode",
+			config: RetryConfig{
+				MaxAttempts:  3,
+				InitialDelay: 1 * time.Second,
+				MaxDelay:     5 * time.Second,
+				Multiplier:   2.0,
+				TotalWaitCap: 10 * time.Second,
+				JitterMode:   JitterConstant + 1,
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -154,7 +170,7 @@ func TestExponentialBackoffWithJitter(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Run multiple times due to randomness
 			for i := 0; i < 10; i++ {
-				result := exponentialBackoffWithJitter(cfg, tt.attempt, tt.retryAfter)
+				result := exponentialBackoffWithJitter(cfg, tt.attempt, tt.retryAfter, 0)
 				if result < tt.minExpect || result > tt.maxExpect {
 					t.Errorf("exponentialBackoffWithJitter() = %v, want between %v and %v",
 						result, tt.minExpect, tt.maxExpect)
@@ -170,16 +186,206 @@ func TestExponentialBackoffWithJitter_RetryAfterPriority(t *testing.T) {
 		MaxDelay:          10 * time.Second,
 		Multiplier:        2.0,
 		RespectRetryAfter: true,
+		RetryAfterMax:     30 * time.Second,
 	}
 
 	retryAfter := 15 * time.Second
-	result := exponentialBackoffWithJitter(cfg, 0, retryAfter)
+	result := exponentialBackoffWithJitter(cfg, 0, retryAfter, 0)
 
 	if result != retryAfter {
 		t.Errorf("exponentialBackoffWithJitter() with Retry-After = %v, want %v", result, retryAfter)
 	}
 }
 
+func TestExponentialBackoffWithJitter_RetryAfterMax(t *testing.T) {
+	baseCfg := RetryConfig{
+		InitialDelay:      1 * time.Second,
+		MaxDelay:          10 * time.Second,
+		Multiplier:        2.0,
+		RespectRetryAfter: true,
+	}
+	retryAfter := 15 * time.Second
+
+	t.Run("cap shorter than header", func(t *testing.T) {
+		cfg := baseCfg
+		cfg.RetryAfterMax = 5 * time.Second
+		if result := exponentialBackoffWithJitter(cfg, 0, retryAfter, 0); result != cfg.RetryAfterMax {
+			t.Errorf("expected capped delay %v, got %v", cfg.RetryAfterMax, result)
+		}
+	})
+
+	t.Run("cap longer than header", func(t *testing.T) {
+		cfg := baseCfg
+		cfg.RetryAfterMax = 30 * time.Second
+		if result := exponentialBackoffWithJitter(cfg, 0, retryAfter, 0); result != retryAfter {
+			t.Errorf("expected header value %v to pass through uncapped, got %v", retryAfter, result)
+		}
+	})
+
+	t.Run("zero cap ignores header entirely", func(t *testing.T) {
+		cfg := baseCfg
+		cfg.RetryAfterMax = 0
+		result := exponentialBackoffWithJitter(cfg, 0, retryAfter, 0)
+		if result == retryAfter {
+			t.Errorf("expected zero RetryAfterMax to ignore the header, got the header value %v back", result)
+		}
+		if result > cfg.MaxDelay {
+			t.Errorf("expected fallback exponential backoff capped at MaxDelay %v, got %v", cfg.MaxDelay, result)
+		}
+	})
+}
+
+func TestExponentialBackoffWithJitter_EqualJitterBoundedVariance(t *testing.T) {
+	cfg := RetryConfig{
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     1 * time.Second,
+		Multiplier:   2.0,
+		JitterMode:   JitterEqual,
+	}
+
+	minSeen, maxSeen := time.Duration(0), time.Duration(0)
+	for i := 0; i < 1000; i++ {
+		result := exponentialBackoffWithJitter(cfg, 0, 0, 0)
+		if result < cfg.InitialDelay/2 || result > cfg.InitialDelay {
+			t.Fatalf("JitterEqual sample %v outside [half, full] = [%v, %v]", result, cfg.InitialDelay/2, cfg.InitialDelay)
+		}
+		if minSeen == 0 || result < minSeen {
+			minSeen = result
+		}
+		if result > maxSeen {
+			maxSeen = result
+		}
+	}
+
+	// Equal jitter should actually vary rather than collapsing to a single
+	// value; the spread should cover a meaningful share of the half-to-full
+	// range across 1000 samples.
+	if maxSeen-minSeen < cfg.InitialDelay/4 {
+		t.Errorf("expected JitterEqual to show meaningful variance across samples, min=%v max=%v", minSeen, maxSeen)
+	}
+}
+
+func TestExponentialBackoffWithJitter_Decorrelated(t *testing.T) {
+	cfg := RetryConfig{
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2.0,
+		JitterMode:   JitterDecorrelated,
+	}
+
+	var prev time.Duration
+	sawGrowth := false
+	sawCap := false
+	for i := 0; i < 1000; i++ {
+		delay := exponentialBackoffWithJitter(cfg, i, 0, prev)
+
+		if delay < cfg.InitialDelay {
+			t.Fatalf("sample %d: delay %v below base delay %v", i, delay, cfg.InitialDelay)
+		}
+		if delay > cfg.MaxDelay {
+			t.Fatalf("sample %d: delay %v exceeds MaxDelay %v", i, delay, cfg.MaxDelay)
+		}
+		if delay > prev {
+			sawGrowth = true
+		}
+		if delay == cfg.MaxDelay {
+			sawCap = true
+		}
+
+		prev = delay
+	}
+
+	if !sawGrowth {
+		t.Error("expected decorrelated jitter to grow beyond the previous delay at least once across 1000 samples")
+	}
+	if !sawCap {
+		t.Error("expected decorrelated jitter to eventually saturate at MaxDelay across 1000 samples")
+	}
+}
+
+func TestExponentialBackoffWithJitter_Constant(t *testing.T) {
+	cfg := RetryConfig{
+		InitialDelay: 250 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2.0,
+		JitterMode:   JitterConstant,
+	}
+
+	var prev time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		delay := exponentialBackoffWithJitter(cfg, attempt, 0, prev)
+		if delay != cfg.InitialDelay {
+			t.Errorf("attempt %d: JitterConstant delay = %v, want exactly %v", attempt, delay, cfg.InitialDelay)
+		}
+		prev = delay
+	}
+}
+
+// fakeClock is a deterministic Clock for tests: Now() is fixed unless
+// advanced, and After() fires immediately rather than sleeping in real time,
+// so retry-loop tests don't have to wait out real backoff delays.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	ch <- now
+	return ch
+}
+
+func TestGetCardsWithRetry_FakeClockAdvancesWithoutSleeping(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {"pagination": {"all_pages": 1, "current_page": 1, "results_per_page": 200}, "data": []}}`))
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewClient(server.URL, "test-api-key", WithClock(clock))
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+	cfg := RetryConfig{
+		MaxAttempts:  5,
+		InitialDelay: time.Hour, // would block the test for hours on a real clock
+		MaxDelay:     time.Hour,
+		Multiplier:   2.0,
+		TotalWaitCap: 24 * time.Hour,
+		JitterMode:   JitterConstant,
+	}
+
+	start := time.Now()
+	if _, err := client.GetCardsWithRetry(context.Background(), filter, cfg, false); err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the fake clock to avoid real sleeping, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&requestCount) != 3 {
+		t.Errorf("expected 3 requests, got %d", requestCount)
+	}
+}
+
 func TestReadCardWithRetry_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.Contains(r.URL.Path, "/cards/1001") && !strings.Contains(r.URL.Path, "/comments") && !strings.Contains(r.URL.Path, "/subtasks") {
@@ -454,3 +660,526 @@ func TestReadCardWithRetry_FailOnPartial(t *testing.T) {
 		t.Errorf("Expected partial data with title 'Test Card', got '%s'", response.Data.Title)
 	}
 }
+
+func TestReadCardWithRetry_CircuitBreakerTripsAfterConsecutiveFailures(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{"error": "down for maintenance"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithCircuitBreaker(2, time.Minute))
+	cfg := RetryConfig{
+		MaxAttempts:  1,
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+		TotalWaitCap: time.Second,
+	}
+
+	ctx := context.Background()
+
+	// Two failing calls trip the breaker (threshold == 2).
+	if _, err := client.ReadCardWithRetry(ctx, "1001", cfg, false); err == nil {
+		t.Fatal("expected first call to fail")
+	}
+	if _, err := client.ReadCardWithRetry(ctx, "1001", cfg, false); err == nil {
+		t.Fatal("expected second call to fail")
+	}
+
+	requestsBeforeOpen := atomic.LoadInt32(&requestCount)
+
+	_, err := client.ReadCardWithRetry(ctx, "1001", cfg, false)
+	if err == nil {
+		t.Fatal("expected third call to fail once the breaker is open")
+	}
+
+	var circuitErr *CircuitOpenError
+	if !errors.As(err, &circuitErr) {
+		t.Errorf("expected a CircuitOpenError, got: %v", err)
+	}
+
+	if atomic.LoadInt32(&requestCount) != requestsBeforeOpen {
+		t.Errorf("expected no new requests once the breaker is open, went from %d to %d", requestsBeforeOpen, requestCount)
+	}
+}
+
+func TestReadCardWithRetry_FetchesRunConcurrently(t *testing.T) {
+	const perRequestDelay = 50 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(perRequestDelay)
+		switch {
+		case strings.Contains(r.URL.Path, "/comments"):
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CommentsResponse{Data: []CommentData{}})
+		case strings.Contains(r.URL.Path, "/subtasks"):
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(SubtasksResponse{Data: []SubtaskData{}})
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CardDataResponse{Data: CardData{CardID: 1001, Title: "Test Card"}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := RetryConfig{
+		MaxAttempts:  1,
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+		TotalWaitCap: time.Second,
+	}
+
+	ctx := context.Background()
+	start := time.Now()
+	response, err := client.ReadCardWithRetry(ctx, "1001", cfg, false)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !response.Completed["card"] || !response.Completed["comments"] || !response.Completed["subtasks"] {
+		t.Fatalf("expected all three fetches to complete, got %v", response.Completed)
+	}
+	if elapsed >= 3*perRequestDelay {
+		t.Errorf("expected card/comments/subtasks to fetch concurrently, took %v (>= %v as if sequential)", elapsed, 3*perRequestDelay)
+	}
+}
+
+func TestReadCardWithRetry_SharedBudgetExhausted_SubtasksSkipsFinalRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/comments"):
+			// Fails fast, so it's first to draw against the shared budget.
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(map[string]string{"error": "unavailable"})
+		case strings.Contains(r.URL.Path, "/subtasks"):
+			// Slower to fail, so its retry is the one starved of budget.
+			time.Sleep(30 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(map[string]string{"error": "unavailable"})
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CardDataResponse{Data: CardData{CardID: 1001, Title: "Test Card"}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     100 * time.Millisecond,
+		Multiplier:   2.0,
+		JitterMode:   JitterNone,
+		TotalWaitCap: 150 * time.Millisecond,
+	}
+
+	ctx := context.Background()
+	response, err := client.ReadCardWithRetry(ctx, "1001", cfg, false)
+
+	if err != nil {
+		t.Fatalf("expected no top-level error (card succeeded), got %v", err)
+	}
+	if response.Completed["comments"] {
+		t.Error("expected comments to keep failing, not succeed")
+	}
+	if response.Completed["subtasks"] {
+		t.Error("expected subtasks to be starved of the shared retry budget")
+	}
+	if !strings.Contains(response.PartialError["subtasks"], "shared retry budget exhausted") {
+		t.Errorf("expected subtasks to report a shared budget exhaustion error, got: %q", response.PartialError["subtasks"])
+	}
+}
+
+func TestFetchGroup_RateLimitedPeersShareDeadlineNotSum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(map[string]string{"error": "rate limited"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := RetryConfig{
+		MaxAttempts:       3,
+		InitialDelay:      10 * time.Millisecond,
+		MaxDelay:          10 * time.Millisecond,
+		Multiplier:        2.0,
+		JitterMode:        JitterNone,
+		RespectRetryAfter: true,
+		RetryAfterMax:     5 * time.Second,
+		TotalWaitCap:      150 * time.Millisecond,
+	}
+
+	specs := []EndpointSpec{
+		{Name: "a", URL: server.URL + "/a"},
+		{Name: "b", URL: server.URL + "/b"},
+		{Name: "c", URL: server.URL + "/c"},
+	}
+
+	start := time.Now()
+	results, err := client.FetchGroup(context.Background(), cfg, specs)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("expected no group-level error (no spec is Required), got %v", err)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if results[name].success {
+			t.Errorf("expected %s to keep failing against a 429-only server", name)
+		}
+	}
+	// Each 429 asks for a 1s Retry-After, but the group shares one 150ms
+	// TotalWaitCap: if the budget were per-goroutine instead of shared,
+	// three peers could each wait out their own cap and this would take
+	// ~450ms or more (and real per-server waits would take seconds).
+	if elapsed >= 300*time.Millisecond {
+		t.Errorf("expected the shared budget to cap total wait well under the sum of per-goroutine waits, took %v", elapsed)
+	}
+}
+
+func TestReadCardWithRetry_ContextCancellationPropagates(t *testing.T) {
+	block := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer server.Close()
+	defer close(block)
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+		TotalWaitCap: time.Second,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ReadCardWithRetry(ctx, "1001", cfg, false)
+	if err == nil {
+		t.Fatal("expected an error once the context is canceled")
+	}
+	if !strings.Contains(err.Error(), "context canceled") && !strings.Contains(err.Error(), "context deadline exceeded") {
+		t.Errorf("expected a context cancellation error, got: %v", err)
+	}
+}
+
+func TestMakeRequestWithRetry_RetryBudgetExhaustedAbortsBackoff(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithRetryBudget(0, 0, 0))
+
+	err := client.AddCardCommentContext(context.Background(), "1001", "hello")
+	if err == nil {
+		t.Fatal("expected an error once the retry token bucket is empty")
+	}
+	if !strings.Contains(err.Error(), "retry budget exhausted") {
+		t.Errorf("expected a retry budget exhausted error, got: %v", err)
+	}
+	if atomic.LoadInt32(&requestCount) != 1 {
+		t.Errorf("expected exactly 1 request (no retries once the budget is empty), got %d", requestCount)
+	}
+}
+
+func TestGetCardsWithRetry_RetryBudgetExhaustedAbortsBackoff(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithRetryBudget(0, 0, 0))
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+
+	_, err := client.GetCardsWithRetry(context.Background(), filter, DefaultRetryConfig(), false)
+	if err == nil {
+		t.Fatal("expected an error once the client-wide retry budget is empty")
+	}
+	if !strings.Contains(err.Error(), "retry budget exhausted") {
+		t.Errorf("expected a retry budget exhausted error, got: %v", err)
+	}
+	if atomic.LoadInt32(&requestCount) != 1 {
+		t.Errorf("expected exactly 1 request (no retries once the budget is empty), got %d", requestCount)
+	}
+}
+
+func TestReadCardWithRetry_RetryBudgetExhaustedAbortsBackoff(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithRetryBudget(0, 0, 0))
+
+	response, err := client.ReadCardWithRetry(context.Background(), "1001", DefaultRetryConfig(), false)
+	if err == nil {
+		t.Fatal("expected an error once the client-wide retry budget is empty (the required card fetch fails)")
+	}
+	if !strings.Contains(err.Error(), "retry budget exhausted") {
+		t.Errorf("expected a retry budget exhausted error, got: %v", err)
+	}
+	for _, name := range []string{"card", "comments", "subtasks"} {
+		if !strings.Contains(response.PartialError[name], "retry budget exhausted") {
+			t.Errorf("expected %s to report a retry budget exhausted partial error, got: %q", name, response.PartialError[name])
+		}
+	}
+	// The client-wide budget is shared across all three concurrent
+	// sub-fetches, so only the first attempt of each should ever reach the
+	// server once it's empty.
+	if got := atomic.LoadInt32(&requestCount); got != 3 {
+		t.Errorf("expected exactly 3 requests (one per sub-fetch, no retries once the budget is empty), got %d", got)
+	}
+}
+
+func TestClampToDeadline(t *testing.T) {
+	tests := []struct {
+		name         string
+		remaining    time.Duration
+		backoffDelay time.Duration
+		wantDelay    time.Duration
+		wantOK       bool
+	}{
+		{"no deadline", 0, 500 * time.Millisecond, 500 * time.Millisecond, true},
+		{"deadline shorter than first backoff", 20 * time.Millisecond, time.Second, 20 * time.Millisecond, true},
+		{"deadline reached mid-sleep budget", 7 * time.Millisecond, 10 * time.Millisecond, 7 * time.Millisecond, true},
+		{"deadline already passed", -5 * time.Millisecond, 10 * time.Millisecond, 0, false},
+		{"backoff already shorter than remaining", time.Minute, 5 * time.Millisecond, 5 * time.Millisecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var cancel context.CancelFunc
+			if tt.remaining != 0 {
+				ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(tt.remaining))
+				defer cancel()
+			}
+
+			got, ok := clampToDeadline(ctx, tt.backoffDelay)
+			if ok != tt.wantOK {
+				t.Fatalf("clampToDeadline() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			// Allow a small tolerance since "remaining" is recomputed from
+			// time.Now() inside clampToDeadline, a few microseconds after
+			// the deadline above was derived from it.
+			tolerance := 5 * time.Millisecond
+			if diff := got - tt.wantDelay; diff > tolerance || diff < -tolerance {
+				t.Errorf("clampToDeadline() delay = %v, want ~%v", got, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestGetCardsWithRetry_ContextDeadlineStopsRetriesEarly(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+	cfg := RetryConfig{
+		MaxAttempts:  10,
+		InitialDelay: time.Second,
+		MaxDelay:     time.Second,
+		Multiplier:   2.0,
+		JitterMode:   JitterConstant,
+		TotalWaitCap: time.Minute,
+	}
+
+	// The deadline is far shorter than even the first unclamped backoff
+	// (1s), so a retry loop that ignores ctx's deadline would block for
+	// seconds; one that honors it gives up within roughly the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetCardsWithRetry(ctx, filter, cfg, false)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error once the context deadline is reached")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a context.DeadlineExceeded error, got: %v", err)
+	}
+	if elapsed >= 500*time.Millisecond {
+		t.Errorf("expected the retry loop to give up around the 30ms deadline instead of sleeping the full backoff, took %v", elapsed)
+	}
+}
+
+func TestAddCardCommentContext_ParentCancellationPropagatesDuringBackoff(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+
+	// Cancel the parent context partway through what would otherwise be the
+	// backoff sleep after the first failed attempt (full jitter of up to
+	// 200ms, defaultClientRetryConfig's BaseBackoff), so the retry loop must
+	// wake on ctx.Done() instead of riding out the full backoff.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := client.AddCardCommentContext(ctx, "1001", "hello")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error once the parent context is canceled")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a context.Canceled error, got: %v", err)
+	}
+	if elapsed >= 500*time.Millisecond {
+		t.Errorf("expected cancellation to interrupt the backoff sleep quickly, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&requestCount) != 1 {
+		t.Errorf("expected exactly 1 request before cancellation interrupted the backoff, got %d", requestCount)
+	}
+}
+
+func TestStatusCodeMatches(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		statusCode int
+		want       bool
+	}{
+		{"429", 429, true},
+		{"429", 503, false},
+		{"5xx", 500, true},
+		{"5xx", 599, true},
+		{"5xx", 499, false},
+		{"5XX", 503, true},
+		{"4xx", 408, true},
+		{"not-a-pattern", 503, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s_vs_%d", tt.pattern, tt.statusCode), func(t *testing.T) {
+			if got := statusCodeMatches(tt.pattern, tt.statusCode); got != tt.want {
+				t.Errorf("statusCodeMatches(%q, %d) = %v, want %v", tt.pattern, tt.statusCode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCardsWithRetry_RetryStatusCodesDefaultDoesNotRetry408(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusRequestTimeout)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+	cfg := RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 5 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+		JitterMode:   JitterConstant,
+		TotalWaitCap: time.Second,
+	}
+
+	_, err := client.GetCardsWithRetry(context.Background(), filter, cfg, false)
+	if err == nil {
+		t.Fatal("expected an error since 408 is not retryable by default")
+	}
+	if atomic.LoadInt32(&requestCount) != 1 {
+		t.Errorf("expected exactly 1 attempt since 408 isn't in defaultRetryableStatusCodes, got %d", requestCount)
+	}
+}
+
+func TestGetCardsWithRetry_RetryStatusCodesOverrideRetries408(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requestCount, 1)
+		if n == 1 {
+			w.WriteHeader(http.StatusRequestTimeout)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":{"pagination":{"all_pages":1,"current_page":1,"results_per_page":200},"data":[]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+	cfg := RetryConfig{
+		MaxAttempts:      3,
+		InitialDelay:     5 * time.Millisecond,
+		MaxDelay:         10 * time.Millisecond,
+		Multiplier:       2.0,
+		JitterMode:       JitterConstant,
+		TotalWaitCap:     time.Second,
+		RetryStatusCodes: []string{"408"},
+	}
+
+	_, err := client.GetCardsWithRetry(context.Background(), filter, cfg, false)
+	if err != nil {
+		t.Fatalf("expected the 408 to be retried and the second attempt to succeed, got %v", err)
+	}
+	if atomic.LoadInt32(&requestCount) != 2 {
+		t.Errorf("expected 2 attempts, got %d", requestCount)
+	}
+}
+
+func TestGetCardsWithRetry_RetryStatusCodesOverrideDropsDefault503(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	filter := GetCardsRequest{BoardIDs: []int{1}}
+	cfg := RetryConfig{
+		MaxAttempts:      3,
+		InitialDelay:     5 * time.Millisecond,
+		MaxDelay:         10 * time.Millisecond,
+		Multiplier:       2.0,
+		JitterMode:       JitterConstant,
+		TotalWaitCap:     time.Second,
+		RetryStatusCodes: []string{"408"},
+	}
+
+	_, err := client.GetCardsWithRetry(context.Background(), filter, cfg, false)
+	if err == nil {
+		t.Fatal("expected an error since RetryStatusCodes fully overrides the 503 default")
+	}
+	if atomic.LoadInt32(&requestCount) != 1 {
+		t.Errorf("expected exactly 1 attempt since 503 was dropped by the override, got %d", requestCount)
+	}
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sharedBudget is a TotalWaitCap shared across several concurrent retry
+// loops (e.g. ReadCardWithRetry's card/comments/subtasks fetches), so the
+// combined backoff time across all of them is bounded rather than each
+// loop getting its own independent cap.
+type sharedBudget struct {
+	mu        sync.Mutex
+	remaining time.Duration
+}
+
+func newSharedBudget(total time.Duration) *sharedBudget {
+	return &sharedBudget{remaining: total}
+}
+
+// reserve atomically deducts d from the remaining budget and reports
+// whether there was enough left to do so. A failed reserve leaves the
+// budget untouched.
+func (b *sharedBudget) reserve(d time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if d > b.remaining {
+		return false
+	}
+	b.remaining -= d
+	return true
+}
+
+// breakerFailureGuard makes one FetchGroup call's concurrent sub-fetches
+// count as a single failure against a host's circuit breaker, no matter how
+// many of them (or how many of their own retry attempts) actually fail.
+// Without it, a logical call's own internal retries - e.g. ReadCardWithRetry
+// fanning out card/comments/subtasks concurrently - could trip the shared
+// per-host breaker purely on their own, poisoning it for unrelated calls
+// that happen to land while this one is still in flight.
+type breakerFailureGuard struct {
+	mu      sync.Mutex
+	counted map[*circuitBreaker]bool
+}
+
+func newBreakerFailureGuard() *breakerFailureGuard {
+	return &breakerFailureGuard{counted: make(map[*circuitBreaker]bool)}
+}
+
+// recordFailure calls breaker.recordFailure() the first time it's asked to
+// for a given breaker, and does nothing on subsequent calls - so only the
+// first of this call's concurrent sub-fetches to fail against a given host
+// actually counts toward that host's breaker.
+func (g *breakerFailureGuard) recordFailure(breaker *circuitBreaker) {
+	g.mu.Lock()
+	already := g.counted[breaker]
+	g.counted[breaker] = true
+	g.mu.Unlock()
+
+	if !already {
+		breaker.recordFailure()
+	}
+}
+
+// fetchWithRetryShared is fetchWithRetry's counterpart for callers that fan
+// out several concurrent fetches against one TotalWaitCap: instead of each
+// loop tracking its own wait budget, every backoff is deducted from the
+// shared budget, and a goroutine that would overdraw it returns a partial
+// error immediately rather than sleeping. sem bounds how many of the
+// sharing goroutines may have an attempt in flight at once
+// (RetryConfig.MaxConcurrency). guard ensures the group's own retries only
+// count once against the host's circuit breaker; see breakerFailureGuard.
+func (c *Client) fetchWithRetryShared(ctx context.Context, cfg RetryConfig, name, url string, budget *sharedBudget, sem chan struct{}, guard *breakerFailureGuard) *endpointResult {
+	result := &endpointResult{name: name}
+
+	breaker, host := c.circuitBreakerFor(url)
+	observer := cfg.observerFor()
+	var lastErr error
+	var prevDelay time.Duration
+	totalWaitTime := time.Duration(0)
+	startTime := c.clock.Now()
+
+	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+		result.attempts = attempt + 1
+
+		select {
+		case <-ctx.Done():
+			result.err = fmt.Errorf("request canceled: %w", ctx.Err())
+			result.reason = reasonForContextErr(ctx.Err())
+			return result
+		default:
+		}
+
+		if err := c.awaitRateLimit(ctx, cfg, url); err != nil {
+			if rlErr, ok := err.(*ErrRateLimited); ok {
+				result.attempts = attempt
+				result.err = rlErr
+				result.reason = ReasonRateLimited
+				return result
+			}
+			result.err = err
+			result.reason = reasonForContextErr(ctx.Err())
+			return result
+		}
+
+		if allowed, coolsDown := breaker.allow(); !allowed {
+			c.metrics.observeCircuitState(host, breaker.currentState())
+			result.err = &CircuitOpenError{Host: host, CoolsDown: coolsDown}
+			result.reason = ReasonCircuitOpen
+			return result
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			result.err = fmt.Errorf("request canceled: %w", ctx.Err())
+			result.reason = reasonForContextErr(ctx.Err())
+			return result
+		}
+		attemptStart := c.clock.Now()
+		data, resp, err := c.doRequest(ctx, "GET", url, nil, attempt)
+		<-sem
+		c.recordRateLimitResponse(url, resp)
+
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		rateLimitCategory := ""
+		if _, ok := isRateLimitError(err); ok {
+			rateLimitCategory = categoryForURL(url)
+		}
+		observer.OnAttempt(ctx, RetryEvent{
+			Name:              name,
+			URL:               url,
+			Attempt:           attempt + 1,
+			MaxAttempts:       cfg.MaxAttempts,
+			StatusCode:        statusCode,
+			Duration:          c.clock.Now().Sub(attemptStart),
+			Err:               err,
+			RateLimitCategory: rateLimitCategory,
+		})
+		rec := AttemptRecord{
+			Name:       name,
+			Attempt:    attempt + 1,
+			StartTime:  attemptStart,
+			Duration:   c.clock.Now().Sub(attemptStart),
+			StatusCode: statusCode,
+			Err:        err,
+		}
+
+		if err == nil {
+			breaker.recordSuccess()
+			c.metrics.observeCircuitState(host, breaker.currentState())
+			c.retryTokens.creditSuccess(c.retryTokenSuccessRate)
+			result.success = true
+			result.data = data
+			result.reason = ReasonSuccess
+			c.recordAttempt(&result.trace, rec)
+			if attempt > 0 {
+				observer.OnSuccess(ctx, attempt+1, totalWaitTime)
+			}
+			return result
+		}
+
+		rateLimitErr, isRateLimit := isRateLimitError(err)
+		if isRateLimit {
+			result.rateLimitHits++
+		}
+
+		if !classifyRetryable(cfg, resp, err) {
+			result.err = err
+			result.reason = ReasonNonRetryableStatus
+			c.recordAttempt(&result.trace, rec)
+			return result
+		}
+
+		guard.recordFailure(breaker)
+		c.metrics.observeCircuitState(host, breaker.currentState())
+		lastErr = err
+
+		if attempt >= cfg.MaxAttempts-1 {
+			result.err = fmt.Errorf("max retries exceeded: %w", lastErr)
+			result.reason = ReasonAttemptsExhausted
+			c.recordAttempt(&result.trace, rec)
+			observer.OnGiveUp(ctx, result.err)
+			return result
+		}
+
+		var retryAfter time.Duration
+		if isRateLimit {
+			retryAfter = rateLimitErr.RetryAfter
+		}
+
+		backoffDelay := exponentialBackoffWithJitter(cfg, attempt, retryAfter, prevDelay)
+		prevDelay = backoffDelay
+
+		if !c.retryTokens.acquire() {
+			result.err = fmt.Errorf("retry budget exhausted: %w", lastErr)
+			result.reason = ReasonAttemptsExhausted
+			c.recordAttempt(&result.trace, rec)
+			observer.OnGiveUp(ctx, result.err)
+			return result
+		}
+
+		if !budget.reserve(backoffDelay) {
+			result.err = fmt.Errorf("shared retry budget exhausted: %w", lastErr)
+			result.reason = ReasonBudgetExhausted
+			c.recordAttempt(&result.trace, rec)
+			observer.OnGiveUp(ctx, result.err)
+			return result
+		}
+
+		rec.RetryAfterUsed = isRateLimit && retryAfter > 0
+		rec.SleepBeforeNext = backoffDelay
+		c.recordAttempt(&result.trace, rec)
+
+		observer.OnBackoff(ctx, backoffDelay, fmt.Sprintf("%s attempt %d/%d failed (%v, shared budget)", name, attempt+1, cfg.MaxAttempts, err))
+
+		select {
+		case <-ctx.Done():
+			result.err = fmt.Errorf("request canceled during backoff: %w", ctx.Err())
+			result.reason = reasonForContextErr(ctx.Err())
+			return result
+		case <-c.clock.After(backoffDelay):
+			totalWaitTime = c.clock.Now().Sub(startTime)
+		}
+	}
+
+	result.err = fmt.Errorf("max retries exceeded: %w", lastErr)
+	result.reason = ReasonAttemptsExhausted
+	return result
+}
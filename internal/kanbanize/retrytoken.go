@@ -0,0 +1,100 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Defaults for a Client's retry token bucket, used unless overridden via
+// WithRetryBudget.
+const (
+	defaultRetryTokenCapacity    = 10.0
+	defaultRetryTokenRefillRate  = 10.0 // tokens/sec
+	defaultRetryTokenSuccessRate = 0.05 // fraction of successes crediting a token
+)
+
+// retryTokenBucket is a per-Client cap on how many retry backoff waits may be
+// scheduled, independent of any single call's own RetryConfig. Modeled on the
+// AWS SDK v2 retry token bucket: capacity tokens drain as retries are
+// scheduled and refill continuously at refillRate, with a configurable
+// fraction of successful requests crediting a token back early so a healthy
+// instance recovers its budget faster than the baseline refill alone. This
+// keeps a broken BusinessMap instance from letting every in-flight
+// ReadCard/AddCardComment/GetCardsWithRetry call burn its own independent
+// retry budget at once.
+type retryTokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	capacity   float64
+	refillRate float64
+	last       time.Time
+}
+
+func newRetryTokenBucket(capacity, refillRate float64) *retryTokenBucket {
+	return &retryTokenBucket{
+		tokens:     capacity,
+		capacity:   capacity,
+		refillRate: refillRate,
+		last:       time.Now(),
+	}
+}
+
+// refillLocked must be called with b.mu held.
+func (b *retryTokenBucket) refillLocked() {
+	now := time.Now()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.refillRate)
+		b.last = now
+	}
+}
+
+// acquire reports whether a token was available to schedule a retry backoff
+// wait, consuming it if so. A nil bucket always allows the wait; NewClient
+// always installs a bucket (the defaults above unless WithRetryBudget is
+// used), so only a Client not built by NewClient has a nil one.
+func (b *retryTokenBucket) acquire() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refillLocked()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// creditSuccess returns a token for a successRate fraction of successful
+// requests, mirroring the AWS SDK v2 approach of letting healthy traffic
+// replenish the bucket faster than the baseline refill rate alone.
+func (b *retryTokenBucket) creditSuccess(successRate float64) {
+	if b == nil || successRate <= 0 || rand.Float64() >= successRate {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refillLocked()
+	if b.tokens < b.capacity {
+		b.tokens++
+	}
+}
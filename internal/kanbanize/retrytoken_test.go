@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryTokenBucket_AcquireDrainsAndRefills(t *testing.T) {
+	b := newRetryTokenBucket(2, 1000) // fast refill so the test doesn't sleep long
+
+	if !b.acquire() {
+		t.Fatal("expected first token to be available")
+	}
+	if !b.acquire() {
+		t.Fatal("expected second token to be available")
+	}
+	if b.acquire() {
+		t.Fatal("expected bucket to be drained after capacity tokens acquired")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if !b.acquire() {
+		t.Fatal("expected a token to have refilled")
+	}
+}
+
+func TestRetryTokenBucket_AcquireNilBucketAlwaysAllows(t *testing.T) {
+	var b *retryTokenBucket
+	if !b.acquire() {
+		t.Fatal("expected a nil bucket to always allow acquire")
+	}
+}
+
+func TestRetryTokenBucket_CreditSuccessNeverExceedsCapacity(t *testing.T) {
+	b := newRetryTokenBucket(1, 0)
+	b.creditSuccess(1.0) // rate=1 always credits
+	if !b.acquire() {
+		t.Fatal("expected the bucket to still have its one token")
+	}
+	if b.acquire() {
+		t.Fatal("expected creditSuccess not to exceed capacity")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import "time"
+
+// AttemptRecord captures full detail about one HTTP attempt made by a retry
+// loop, including attempts that ultimately failed. Only the most recent
+// attempt's error is ever returned as a call's err (matching tikv/pd's
+// conclusion on dropping multierr-style error aggregation), but the full
+// per-attempt history is retained here for observability: debugging a flaky
+// BusinessMap tenant often means seeing that 3 of 5 attempts failed for three
+// different reasons, not just the last one.
+type AttemptRecord struct {
+	// Name labels the endpoint for fan-out callers (e.g. "comments",
+	// "subtasks"); empty for single-endpoint callers like makeRequestWithRetry.
+	Name string
+	// Attempt is 1-indexed, matching RetryEvent.Attempt.
+	Attempt int
+
+	StartTime  time.Time
+	Duration   time.Duration
+	StatusCode int
+
+	// RetryAfterUsed reports whether this attempt's backoff honored a
+	// server-supplied Retry-After/rate-limit hint rather than pure
+	// exponential backoff.
+	RetryAfterUsed bool
+	// SleepBeforeNext is how long the loop slept after this attempt before
+	// making the next one; zero on the attempt that succeeded or gave up.
+	SleepBeforeNext time.Duration
+
+	// Err is this attempt's error, nil on success. It's typically an
+	// *ErrRateLimited, *CircuitOpenError, *HTTPStatusError, or *APIError.
+	Err error
+}
+
+// recordAttempt appends rec to trace and, if the caller installed one via
+// Client.OnRetry, forwards it to that hook.
+func (c *Client) recordAttempt(trace *[]AttemptRecord, rec AttemptRecord) {
+	*trace = append(*trace, rec)
+	if c.onRetry != nil {
+		c.onRetry(rec)
+	}
+}
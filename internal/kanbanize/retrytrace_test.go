@@ -0,0 +1,145 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetCardsWithRetry_RetryTraceRecordsEachAttempt(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error": "try again"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"data": {
+				"pagination": {"all_pages": 1, "current_page": 1, "results_per_page": 200},
+				"data": [{"card_id": 101, "title": "Card 1", "board_id": 1, "lane_id": 10, "workflow_id": 100}]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := RetryConfig{
+		MaxAttempts:  5,
+		InitialDelay: 5 * time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		Multiplier:   2.0,
+		TotalWaitCap: time.Second,
+	}
+
+	response, err := client.GetCardsWithRetry(context.Background(), GetCardsRequest{BoardIDs: []int{1}}, cfg, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(response.RetryTrace) != 3 {
+		t.Fatalf("expected 3 attempt records, got %d: %+v", len(response.RetryTrace), response.RetryTrace)
+	}
+	for i, rec := range response.RetryTrace {
+		if rec.Attempt != i+1 {
+			t.Errorf("record %d: expected Attempt=%d, got %d", i, i+1, rec.Attempt)
+		}
+	}
+	if response.RetryTrace[0].Err == nil || response.RetryTrace[1].Err == nil {
+		t.Error("expected the first two attempts to carry their failure error")
+	}
+	if response.RetryTrace[2].Err != nil {
+		t.Errorf("expected the final attempt to have no error, got %v", response.RetryTrace[2].Err)
+	}
+	if response.RetryTrace[0].SleepBeforeNext <= 0 {
+		t.Error("expected a nonzero SleepBeforeNext on a retried attempt")
+	}
+	if response.RetryTrace[2].SleepBeforeNext != 0 {
+		t.Error("expected SleepBeforeNext to be zero on the final, successful attempt")
+	}
+}
+
+func TestClient_OnRetry_InvokedOncePerAttempt(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+
+	var mu sync.Mutex
+	var records []AttemptRecord
+	client.OnRetry(func(rec AttemptRecord) {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, rec)
+	})
+
+	cfg := RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 5 * time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		Multiplier:   2.0,
+		TotalWaitCap: time.Second,
+	}
+
+	if _, err := client.makeRequestWithRetry(context.Background(), cfg, "GET", server.URL+"/api/v2/cards/1001", nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 attempt records via OnRetry, got %d: %+v", len(records), records)
+	}
+	if records[0].Err == nil {
+		t.Error("expected the first attempt record to carry its failure error")
+	}
+	if records[1].Err != nil {
+		t.Errorf("expected the second, successful attempt record to have no error, got %v", records[1].Err)
+	}
+}
+
+func TestClient_OnRetry_NilHookIsANoOp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg := DefaultRetryConfig()
+	cfg.MaxAttempts = 1
+
+	if _, err := client.makeRequestWithRetry(context.Background(), cfg, "GET", server.URL+"/api/v2/cards/1001", nil); err != nil {
+		t.Fatalf("expected no error with no OnRetry hook installed, got %v", err)
+	}
+}
@@ -0,0 +1,291 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// EventKind classifies what changed about a card between two polls.
+type EventKind int
+
+const (
+	EventCreated EventKind = iota
+	EventUpdated
+	EventCommentAdded
+	EventSubtaskChanged
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventCreated:
+		return "created"
+	case EventUpdated:
+		return "updated"
+	case EventCommentAdded:
+		return "comment_added"
+	case EventSubtaskChanged:
+		return "subtask_changed"
+	default:
+		return "unknown"
+	}
+}
+
+// CardEvent describes a single detected change to a card, regardless of
+// whether it was observed via polling (Subscribe) or received from a
+// BusinessMap outbound webhook (WebhookHandler).
+type CardEvent struct {
+	Kind   EventKind
+	CardID int
+	Before *CardSummary
+	After  *CardSummary
+}
+
+const (
+	defaultMinPollInterval = 5 * time.Second
+	defaultMaxPollInterval = 2 * time.Minute
+)
+
+// SubscribeOptions configures Client.Subscribe.
+type SubscribeOptions struct {
+	// BoardIDs restricts polling to the given boards. At least one is
+	// required; BusinessMap has no concept of subscribing to "everything".
+	BoardIDs []int
+
+	// WatermarkStore persists the last-seen card LastModified time per board
+	// set so a restarted subscriber can filter its first post-restart poll
+	// to recently changed cards instead of replaying the whole board as
+	// EventCreated. Defaults to an in-memory store, which (being in-memory)
+	// offers no actual resume-after-restart benefit; use FileWatermarkStore
+	// or a custom WatermarkStore for that.
+	WatermarkStore WatermarkStore
+
+	// MinPollInterval and MaxPollInterval bound the adaptive poll interval:
+	// it speeds up toward MinPollInterval while changes are seen, and backs
+	// off toward MaxPollInterval during quiet periods. Default to 5s/2m.
+	MinPollInterval time.Duration
+	MaxPollInterval time.Duration
+
+	// RetryConfig governs per-poll retries and circuit-breaker cooperation;
+	// defaults to DefaultRetryConfig().
+	RetryConfig RetryConfig
+
+	// BufferSize sets the channel buffer for delivered events. Defaults to 64.
+	BufferSize int
+}
+
+// Subscribe streams card change events for the configured boards, polling
+// GET /api/v2/cards on an adaptive interval and diffing each fetch against
+// the previous snapshot (BusinessMap has no WebSocket push API). The
+// returned channel is closed only when ctx is canceled; a failed poll is
+// logged and the loop keeps polling on its backed-off interval.
+//
+// Polling shares the client's retry transport and per-host circuit breaker,
+// so a 429 or an open breaker pauses the poller rather than hammering the
+// API; consecutive quiet polls back the interval off toward MaxPollInterval,
+// and any detected change speeds it back up toward MinPollInterval.
+//
+// Only EventCreated and EventUpdated are ever produced by polling: CardSummary
+// carries no comment/subtask data to diff, so detecting EventCommentAdded or
+// EventSubtaskChanged requires BusinessMap's outbound webhooks instead (see
+// WebhookHandler). A consumer that needs the full set of CardEvent kinds
+// should run both.
+//
+// Each poll, including the first, is filtered to cards modified at or after
+// the watermark opts.WatermarkStore has persisted for this board set (when
+// one exists), so a
+// restarted subscriber resumes from roughly where it left off instead of
+// re-emitting EventCreated for every card already on the board. This is a
+// best-effort resume, not an exact one: the in-memory snapshot diffed
+// against is not itself persisted, so a card modified since the last
+// watermark but already seen in a prior process's lifetime is reported as
+// EventCreated rather than EventUpdated on the first post-restart poll.
+func (c *Client) Subscribe(ctx context.Context, opts SubscribeOptions) (<-chan CardEvent, error) {
+	if len(opts.BoardIDs) == 0 {
+		return nil, fmt.Errorf("subscribe: at least one board ID is required")
+	}
+	if opts.WatermarkStore == nil {
+		opts.WatermarkStore = NewMemoryWatermarkStore()
+	}
+	if opts.MinPollInterval <= 0 {
+		opts.MinPollInterval = defaultMinPollInterval
+	}
+	if opts.MaxPollInterval <= 0 {
+		opts.MaxPollInterval = defaultMaxPollInterval
+	}
+	if opts.MaxPollInterval < opts.MinPollInterval {
+		opts.MaxPollInterval = opts.MinPollInterval
+	}
+	if opts.RetryConfig.MaxAttempts == 0 {
+		opts.RetryConfig = DefaultRetryConfig()
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 64
+	}
+
+	events := make(chan CardEvent, opts.BufferSize)
+	go c.pollLoop(ctx, opts, events)
+	return events, nil
+}
+
+func (c *Client) pollLoop(ctx context.Context, opts SubscribeOptions, events chan<- CardEvent) {
+	defer close(events)
+
+	watermarkKey := subscriptionKey(opts.BoardIDs)
+	snapshot := make(map[int]CardSummary)
+	interval := opts.MinPollInterval
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		changed, err := c.pollOnce(ctx, opts, watermarkKey, snapshot, events)
+		if err != nil {
+			var circuitErr *CircuitOpenError
+			if errors.As(err, &circuitErr) {
+				log.Printf("[SUBSCRIBE] circuit open, pausing: %v", err)
+			} else {
+				log.Printf("[SUBSCRIBE] poll failed: %v", err)
+			}
+		}
+
+		interval = nextPollInterval(interval, changed, opts.MinPollInterval, opts.MaxPollInterval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pollOnce fetches the current card list — filtered to cards modified since
+// the persisted watermark, if one exists — diffs the result against snapshot
+// (mutated in place), emits a CardEvent per detected change, and advances the
+// watermark on success. It reports whether any change was observed, so the
+// caller can adapt its poll interval.
+func (c *Client) pollOnce(ctx context.Context, opts SubscribeOptions, watermarkKey string, snapshot map[int]CardSummary, events chan<- CardEvent) (bool, error) {
+	filter := GetCardsRequest{BoardIDs: opts.BoardIDs}
+	filtered := false
+	if watermark, err := opts.WatermarkStore.Get(ctx, watermarkKey); err != nil {
+		log.Printf("[SUBSCRIBE] failed to read watermark for %s: %v", watermarkKey, err)
+	} else if !watermark.IsZero() {
+		filter.ModifiedSince = &watermark
+		filtered = true
+	}
+
+	resp, err := c.GetCardsWithRetry(ctx, filter, opts.RetryConfig, false)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	seen := make(map[int]bool, len(resp.Cards))
+	var maxModified time.Time
+
+	for _, card := range resp.Cards {
+		seen[card.CardID] = true
+		before, existed := snapshot[card.CardID]
+
+		switch {
+		case !existed:
+			changed = true
+			after := card
+			if !sendEvent(ctx, events, CardEvent{Kind: EventCreated, CardID: card.CardID, After: &after}) {
+				return changed, ctx.Err()
+			}
+		case before != card:
+			changed = true
+			beforeCopy, afterCopy := before, card
+			if !sendEvent(ctx, events, CardEvent{Kind: EventUpdated, CardID: card.CardID, Before: &beforeCopy, After: &afterCopy}) {
+				return changed, ctx.Err()
+			}
+		}
+
+		snapshot[card.CardID] = card
+
+		if card.LastModified == "" {
+			continue
+		}
+		if t, err := c.timeParser.Parse(card.LastModified); err == nil && t != nil && t.After(maxModified) {
+			maxModified = *t
+		}
+	}
+
+	// A filtered poll only ever returns cards modified since the watermark,
+	// so a card absent from resp.Cards this round says nothing about
+	// whether it's still on the board; pruning snapshot here would just
+	// make a future, unrelated modification look like EventCreated instead
+	// of EventUpdated. Only an unfiltered poll sees the full board and can
+	// safely drop cards that have actually left it.
+	if !filtered {
+		for cardID := range snapshot {
+			if !seen[cardID] {
+				delete(snapshot, cardID)
+			}
+		}
+	}
+
+	if !maxModified.IsZero() {
+		if err := opts.WatermarkStore.Set(ctx, watermarkKey, maxModified); err != nil {
+			log.Printf("[SUBSCRIBE] failed to persist watermark for %s: %v", watermarkKey, err)
+		}
+	}
+
+	return changed, nil
+}
+
+// sendEvent delivers ev on events, blocking until it is received or ctx is
+// canceled, and returns false in the latter case. A consumer that stops
+// draining therefore holds the poll loop until ctx is canceled; unlike
+// WebhookHandler, sendEvent never drops an event because the channel is full.
+func sendEvent(ctx context.Context, events chan<- CardEvent, ev CardEvent) bool {
+	select {
+	case events <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// nextPollInterval backs the interval off toward maxInterval when idle, and
+// resets to minInterval as soon as a change is observed.
+func nextPollInterval(current time.Duration, changed bool, minInterval, maxInterval time.Duration) time.Duration {
+	if changed {
+		return minInterval
+	}
+	next := current * 2
+	if next > maxInterval {
+		next = maxInterval
+	}
+	return next
+}
+
+func subscriptionKey(boardIDs []int) string {
+	key := "boards"
+	for _, id := range boardIDs {
+		key += fmt.Sprintf(":%d", id)
+	}
+	return key
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSubscribe_EmitsCreatedThenUpdated(t *testing.T) {
+	var poll int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		poll++
+		var card string
+		if poll == 1 {
+			card = `{"card_id": 1, "title": "Initial"}`
+		} else {
+			card = `{"card_id": 1, "title": "Renamed"}`
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {"pagination": {"all_pages": 1, "current_page": 1, "results_per_page": 200}, "data": [` + card + `]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Subscribe(ctx, SubscribeOptions{
+		BoardIDs:        []int{1},
+		MinPollInterval: 10 * time.Millisecond,
+		MaxPollInterval: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+
+	created := waitForEvent(t, events, EventCreated)
+	if created.CardID != 1 || created.After.Title != "Initial" {
+		t.Errorf("unexpected created event: %+v", created)
+	}
+
+	updated := waitForEvent(t, events, EventUpdated)
+	if updated.After.Title != "Renamed" {
+		t.Errorf("unexpected updated event: %+v", updated)
+	}
+}
+
+func waitForEvent(t *testing.T, events <-chan CardEvent, kind EventKind) CardEvent {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case ev := <-events:
+			if ev.Kind == kind {
+				return ev
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for event kind %v", kind)
+		}
+	}
+}
+
+func TestSubscribe_AdvancesWatermarkFromLastModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {"pagination": {"all_pages": 1, "current_page": 1, "results_per_page": 200}, "data": [{"card_id": 1, "title": "A", "last_modified": "2024-03-01T10:00:00Z"}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	store := NewMemoryWatermarkStore()
+	opts := SubscribeOptions{BoardIDs: []int{1}, RetryConfig: DefaultRetryConfig(), WatermarkStore: store}
+
+	if _, err := client.pollOnce(context.Background(), opts, "boards:1", make(map[int]CardSummary), make(chan CardEvent, 1)); err != nil {
+		t.Fatalf("pollOnce returned error: %v", err)
+	}
+
+	got, err := store.Get(context.Background(), "boards:1")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	want := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("expected the watermark to advance to the card's last_modified %v, got %v", want, got)
+	}
+}
+
+func TestSubscribe_AppliesPersistedWatermarkAsFilter(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {"pagination": {"all_pages": 1, "current_page": 1, "results_per_page": 200}, "data": []}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	store := NewMemoryWatermarkStore()
+	watermark := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := store.Set(context.Background(), "boards:1", watermark); err != nil {
+		t.Fatalf("failed to seed watermark: %v", err)
+	}
+	opts := SubscribeOptions{BoardIDs: []int{1}, RetryConfig: DefaultRetryConfig(), WatermarkStore: store}
+
+	// Simulates a restarted subscriber: the in-memory snapshot starts empty,
+	// but a watermark was already persisted by the previous process.
+	if _, err := client.pollOnce(context.Background(), opts, "boards:1", make(map[int]CardSummary), make(chan CardEvent, 1)); err != nil {
+		t.Fatalf("pollOnce returned error: %v", err)
+	}
+
+	wantParam := "modified_from_date=" + watermark.Format(time.RFC3339)
+	if !strings.Contains(gotQuery, wantParam) {
+		t.Errorf("expected the request to be filtered by the persisted watermark (%q), got query %q", wantParam, gotQuery)
+	}
+}
+
+func TestSubscribe_BlockingSendUnblocksOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {"pagination": {"all_pages": 1, "current_page": 1, "results_per_page": 200}, "data": [{"card_id": 1, "title": "A"}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan CardEvent) // unbuffered, nothing ever reads from it
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		client.pollOnce(ctx, SubscribeOptions{BoardIDs: []int{1}, RetryConfig: DefaultRetryConfig(), WatermarkStore: NewMemoryWatermarkStore()}, "boards:1", make(map[int]CardSummary), events)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("pollOnce did not return after ctx was canceled; the event send is still blocking")
+	}
+}
+
+func TestSubscribe_RequiresBoardIDs(t *testing.T) {
+	client := NewClient("http://example.com", "test-api-key")
+	if _, err := client.Subscribe(context.Background(), SubscribeOptions{}); err == nil {
+		t.Fatal("expected error when no BoardIDs are given")
+	}
+}
+
+func TestWebhookHandler_EnqueuesCardEvent(t *testing.T) {
+	sink := make(chan CardEvent, 1)
+	handler := WebhookHandler(sink)
+
+	body := `{"event": "card_updated", "card": {"card_id": 42, "title": "From webhook"}}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	select {
+	case ev := <-sink:
+		if ev.Kind != EventUpdated || ev.CardID != 42 {
+			t.Errorf("unexpected event: %+v", ev)
+		}
+	default:
+		t.Fatal("expected an event to be enqueued")
+	}
+}
+
+func TestWebhookHandler_RejectsUnknownEvent(t *testing.T) {
+	sink := make(chan CardEvent, 1)
+	handler := WebhookHandler(sink)
+
+	body := `{"event": "card_deleted", "card": {"card_id": 1}}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
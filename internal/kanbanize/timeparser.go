@@ -0,0 +1,91 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultTimeFormats are the layouts BusinessMap has been observed to send
+// timestamps in. They're tried in order; the first one that parses wins.
+var defaultTimeFormats = []string{
+	time.RFC3339,           // "2006-01-02T15:04:05Z07:00"
+	"2006-01-02T15:04:05Z", // RFC3339 without timezone offset
+	"2006-01-02 15:04:05",  // Space-separated format
+	"2006-01-02T15:04:05",  // T-separated without timezone
+	time.RFC3339Nano,       // With nanoseconds
+}
+
+// TimeParser parses a BusinessMap timestamp string into a time.Time. It
+// underlies every timestamp field on ReadCardResponse and Comment.CreatedAt,
+// so an operator on a non-UTC tenant can install one (see WithTimeFormats,
+// WithTimeParser) that understands that tenant's layouts instead of being
+// limited to defaultTimeFormats. Parse("") must return (nil, nil): an empty
+// string means "no timestamp", not a parse failure.
+type TimeParser interface {
+	Parse(raw string) (*time.Time, error)
+}
+
+// defaultTimeParser is the TimeParser every Client uses unless overridden; it
+// reproduces the package's original behavior of trying a fixed list of
+// layouts in order. formats and location are exported to WithTimeFormats and
+// WithTimeLocation so those options can tweak this parser in place without
+// callers having to implement TimeParser themselves.
+type defaultTimeParser struct {
+	formats  []string
+	location *time.Location
+}
+
+func newDefaultTimeParser() *defaultTimeParser {
+	return &defaultTimeParser{formats: defaultTimeFormats}
+}
+
+func (p *defaultTimeParser) Parse(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	loc := p.location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	for _, format := range p.formats {
+		if parsed, err := time.ParseInLocation(format, raw, loc); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unrecognized timestamp format: %q", raw)
+}
+
+// TimestampPolicy controls what ReadCard/ReadCardWithRetry do with a card
+// timestamp field that c.timeParser fails to parse.
+type TimestampPolicy string
+
+const (
+	// PolicyNilOut leaves the field nil, the same as the package's original
+	// behavior. It's the default.
+	PolicyNilOut TimestampPolicy = "nil_out"
+	// PolicyKeepRaw leaves the field nil but records the unparsed string on
+	// ReadCardResponse.RawTimestamps, keyed by field name, so a caller can
+	// still see what BusinessMap actually sent.
+	PolicyKeepRaw TimestampPolicy = "keep_raw"
+	// PolicyError fails the whole ReadCard/ReadCardWithRetry call.
+	PolicyError TimestampPolicy = "error"
+)
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newCardServer(t *testing.T, lastEndTime string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/cards/2001":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CardDataResponse{
+				Data: CardData{
+					CardID:      2001,
+					Title:       "Test Card",
+					LastEndTime: &lastEndTime,
+				},
+			})
+		case "/api/v2/cards/2001/comments":
+			json.NewEncoder(w).Encode(CommentsResponse{Data: []CommentData{}})
+		case "/api/v2/cards/2001/subtasks":
+			json.NewEncoder(w).Encode(SubtasksResponse{Data: []SubtaskData{}})
+		}
+	}))
+}
+
+func TestReadCard_OnParseErrorPolicyNilOut(t *testing.T) {
+	server := newCardServer(t, "not-a-timestamp")
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+
+	response, err := client.ReadCard("2001")
+	if err != nil {
+		t.Fatalf("expected no error under PolicyNilOut, got %v", err)
+	}
+	if response.LastEndTime != nil {
+		t.Errorf("expected LastEndTime to be nil, got %v", *response.LastEndTime)
+	}
+	if response.RawTimestamps != nil {
+		t.Errorf("expected no RawTimestamps under PolicyNilOut, got %v", response.RawTimestamps)
+	}
+}
+
+func TestReadCard_OnParseErrorPolicyKeepRaw(t *testing.T) {
+	server := newCardServer(t, "not-a-timestamp")
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithOnParseError(PolicyKeepRaw))
+
+	response, err := client.ReadCard("2001")
+	if err != nil {
+		t.Fatalf("expected no error under PolicyKeepRaw, got %v", err)
+	}
+	if response.LastEndTime != nil {
+		t.Errorf("expected LastEndTime to be nil, got %v", *response.LastEndTime)
+	}
+	if response.RawTimestamps["last_end_time"] != "not-a-timestamp" {
+		t.Errorf("expected RawTimestamps[\"last_end_time\"] = %q, got %v", "not-a-timestamp", response.RawTimestamps)
+	}
+}
+
+func TestReadCard_OnParseErrorPolicyError(t *testing.T) {
+	server := newCardServer(t, "not-a-timestamp")
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithOnParseError(PolicyError))
+
+	_, err := client.ReadCard("2001")
+	if err == nil {
+		t.Fatal("expected ReadCard to fail under PolicyError")
+	}
+}
+
+func TestReadCard_WithTimeFormatsCustomLayout(t *testing.T) {
+	const layout = "2006-01-02 15:04:05 -0700"
+	server := newCardServer(t, "2024-03-01 09:00:00 +0200")
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key", WithTimeFormats(layout))
+
+	response, err := client.ReadCard("2001")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response.LastEndTime == nil {
+		t.Fatal("expected LastEndTime to be parsed using the custom layout")
+	}
+	want, err := time.Parse(layout, "2024-03-01 09:00:00 +0200")
+	if err != nil {
+		t.Fatalf("test fixture itself failed to parse: %v", err)
+	}
+	if !response.LastEndTime.Equal(want) {
+		t.Errorf("expected LastEndTime = %v, got %v", want, *response.LastEndTime)
+	}
+}
+
+func TestReadCard_WithTimeLocationAppliesToNaiveTimestamps(t *testing.T) {
+	const layout = "2006-01-02 15:04:05"
+	server := newCardServer(t, "2024-03-01 09:00:00")
+	defer server.Close()
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable in this environment: %v", err)
+	}
+
+	client := NewClient(server.URL, "test-api-key", WithTimeFormats(layout), WithTimeLocation(loc))
+
+	response, fetchErr := client.ReadCard("2001")
+	if fetchErr != nil {
+		t.Fatalf("expected no error, got %v", fetchErr)
+	}
+	if response.LastEndTime == nil {
+		t.Fatal("expected LastEndTime to be parsed")
+	}
+	want, _ := time.ParseInLocation(layout, "2024-03-01 09:00:00", loc)
+	if !response.LastEndTime.Equal(want) {
+		t.Errorf("expected LastEndTime = %v (in %v), got %v", want, loc, *response.LastEndTime)
+	}
+}
+
+type fixedTimeParser struct {
+	parsed *time.Time
+	err    error
+}
+
+func (p *fixedTimeParser) Parse(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	return p.parsed, p.err
+}
+
+func TestReadCard_WithTimeParserOverridesDefault(t *testing.T) {
+	server := newCardServer(t, "whatever-the-tenant-sends")
+	defer server.Close()
+
+	fixed := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	client := NewClient(server.URL, "test-api-key", WithTimeParser(&fixedTimeParser{parsed: &fixed}))
+
+	response, err := client.ReadCard("2001")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response.LastEndTime == nil || !response.LastEndTime.Equal(fixed) {
+		t.Errorf("expected LastEndTime = %v, got %v", fixed, response.LastEndTime)
+	}
+}
+
+func TestReadCard_WithTimeParserOverrideIgnoresWithTimeFormats(t *testing.T) {
+	server := newCardServer(t, "x")
+	defer server.Close()
+
+	fixed := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	client := NewClient(
+		server.URL, "test-api-key",
+		WithTimeParser(&fixedTimeParser{parsed: &fixed}),
+		WithTimeFormats("2006-01-02"),
+	)
+
+	response, err := client.ReadCard("2001")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response.LastEndTime == nil || !response.LastEndTime.Equal(fixed) {
+		t.Errorf("expected WithTimeFormats to have no effect once WithTimeParser replaced the parser, got %v", response.LastEndTime)
+	}
+}
+
+func TestDefaultTimeParser_ParseEmptyStringIsNotAnError(t *testing.T) {
+	p := newDefaultTimeParser()
+
+	parsed, err := p.Parse("")
+	if err != nil {
+		t.Fatalf("expected no error for an empty string, got %v", err)
+	}
+	if parsed != nil {
+		t.Errorf("expected nil for an empty string, got %v", parsed)
+	}
+}
+
+func TestDefaultTimeParser_ParseUnrecognizedFormatReturnsError(t *testing.T) {
+	p := newDefaultTimeParser()
+
+	_, err := p.Parse("not a timestamp at all")
+	if err == nil {
+		t.Fatal("expected an error for an unrecognized timestamp format")
+	}
+}
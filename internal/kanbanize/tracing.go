@@ -0,0 +1,81 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"regexp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/hivemq/businessmap-mcp/internal/kanbanize"
+
+// cardIDPattern extracts a card ID from a Kanbanize API URL for the
+// kanbanize.card_id span attribute, e.g. "/api/v2/cards/1001/comments".
+var cardIDPattern = regexp.MustCompile(`/cards/(\d+)`)
+
+// startRequestSpan opens a span for a single outbound HTTP attempt when a
+// tracer is configured, returning a no-op end function otherwise so callers
+// can unconditionally `defer end(...)`.
+func (c *Client) startRequestSpan(ctx context.Context, method, url string, attempt int) (context.Context, func(statusCode int, err error)) {
+	if c.tracer == nil {
+		return ctx, func(int, error) {}
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", method),
+		attribute.Int("kanbanize.retry_attempt", attempt),
+	}
+	if m := cardIDPattern.FindStringSubmatch(url); len(m) == 2 {
+		attrs = append(attrs, attribute.String("kanbanize.card_id", m[1]))
+	}
+
+	ctx, span := c.tracer.Start(ctx, "kanbanize.request", trace.WithAttributes(attrs...))
+	return ctx, func(statusCode int, err error) {
+		if statusCode > 0 {
+			span.SetAttributes(attribute.Int("http.status_code", statusCode))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// startCallSpan opens a span for an entire multi-attempt retry call (e.g.
+// ReadCardWithRetry) when a tracer is configured, so that per-attempt spans
+// and OTelObserver events recorded against the returned ctx nest under one
+// parent instead of showing up as unrelated traces. Returns a no-op end
+// function when no tracer is configured.
+func (c *Client) startCallSpan(ctx context.Context, name string) (context.Context, func(err error)) {
+	if c.tracer == nil {
+		return ctx, func(error) {}
+	}
+
+	ctx, span := c.tracer.Start(ctx, name)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -21,9 +21,43 @@ import (
 	"time"
 )
 
+// APIError represents BusinessMap's structured error body,
+// {"error": "...", "error_code": "..."}, returned alongside a non-2xx status
+// other than 429 (see RateLimitError). HTTPStatus is filled in by the caller
+// that parsed the body, not part of the JSON itself.
 type APIError struct {
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Message    string `json:"error"`
+	Code       string `json:"error_code"`
+	HTTPStatus int    `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("API error (status %d, code %s): %s", e.HTTPStatus, e.Code, e.Message)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.HTTPStatus, e.Message)
+}
+
+// nonRetryableAPICodes lists the BusinessMap error_code values that mean a
+// request is permanently broken rather than transiently failing, mirroring
+// hcloud-go's error classifier: retrying an auth failure, a validation
+// error, a not-found, or a permission error with the same request can never
+// succeed, so the retry loop should give up on the first attempt instead of
+// burning its whole budget. Any other code (e.g. "conflict") is assumed
+// transient and retried normally.
+var nonRetryableAPICodes = map[string]bool{
+	"unauthorized":      true,
+	"invalid_api_key":   true,
+	"forbidden":         true,
+	"permission_denied": true,
+	"not_found":         true,
+	"validation_error":  true,
+}
+
+// Retryable reports whether retrying the request that produced e could
+// plausibly succeed. See nonRetryableAPICodes.
+func (e *APIError) Retryable() bool {
+	return !nonRetryableAPICodes[e.Code]
 }
 
 // RateLimitError represents an HTTP 429 rate limit error with retry information
@@ -40,6 +74,48 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("rate limit exceeded (HTTP %d)", e.StatusCode)
 }
 
+// HTTPStatusError represents a non-2xx API response that isn't a rate limit
+// (see RateLimitError for 429s). Carrying StatusCode as a typed field, rather
+// than only formatting it into the error string, lets retry and
+// circuit-breaker logic classify transient failures (502/503/504) without
+// parsing error text.
+type HTTPStatusError struct {
+	StatusCode int
+	Message    string
+	RawBody    string
+}
+
+func (e *HTTPStatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.RawBody)
+}
+
+// CircuitOpenError is returned when a call is short-circuited because the
+// per-host circuit breaker has tripped and its cool-down has not yet elapsed.
+type CircuitOpenError struct {
+	Host      string
+	CoolsDown time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open for %s until %v", e.Host, e.CoolsDown.Format(time.RFC3339))
+}
+
+// ErrRateLimited is returned instead of issuing a request when the client's
+// proactive, category-scoped rate-limit tracker already knows the category
+// is cooling down (see RateLimitMap), and the caller opted into
+// RetryConfig.SkipIfRateLimited rather than waiting out the cooldown.
+type ErrRateLimited struct {
+	Category   string
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limited for category %q, retry after %v", e.Category, e.RetryAfter)
+}
+
 type ReadCardResponse struct {
 	Title                  string        `json:"title"`
 	Description            string        `json:"description"`
@@ -60,6 +136,10 @@ type ReadCardResponse struct {
 	PlannedEndDate         *string       `json:"planned_end_date,omitempty"`
 	ActualStartTime        *time.Time    `json:"actual_start_time,omitempty"`
 	ActualEndTime          *time.Time    `json:"actual_end_time,omitempty"`
+	// RawTimestamps holds, by field name (e.g. "last_end_time"), the unparsed
+	// string for any timestamp field that failed to parse under
+	// PolicyKeepRaw. It's empty under the default PolicyNilOut.
+	RawTimestamps map[string]string `json:"raw_timestamps,omitempty"`
 }
 
 type Subtask struct {
@@ -157,11 +237,116 @@ type AddCommentData struct {
 
 // ReadCardWithRetryResponse wraps the card data with retry metadata
 type ReadCardWithRetryResponse struct {
-	CardID         string                    `json:"card_id"`
-	Attempts       map[string]int            `json:"attempts"`
-	WaitSeconds    float64                   `json:"wait_seconds"`
-	RateLimitHits  int                       `json:"rate_limit_hits"`
-	Completed      map[string]bool           `json:"completed"`
-	PartialError   map[string]string         `json:"partial_error,omitempty"`
-	Data           *ReadCardResponse         `json:"data"`
+	CardID        string            `json:"card_id"`
+	Attempts      map[string]int    `json:"attempts"`
+	WaitSeconds   float64           `json:"wait_seconds"`
+	RateLimitHits int               `json:"rate_limit_hits"`
+	Completed     map[string]bool   `json:"completed"`
+	PartialError  map[string]string `json:"partial_error,omitempty"`
+	// Reasons records, per sub-fetch, why its retry loop stopped (success,
+	// deadline, canceled, attempts_exhausted, total_wait_cap, ...), so a
+	// caller can decide whether retrying again is worthwhile.
+	Reasons map[string]string `json:"reasons"`
+	Data    *ReadCardResponse `json:"data"`
+	// RetryTrace records every attempt made across all sub-fetches (card,
+	// comments, subtasks), in the order they completed. See AttemptRecord.
+	RetryTrace []AttemptRecord `json:"retry_trace,omitempty"`
+}
+
+// GetCardsRequest filters the cards returned by GetCardsWithRetry. At least
+// one of the typed ID filters or Query must be provided.
+//
+// Query is a JQL-inspired filter expression (see kanbanize/query), e.g.
+// `board_ids IN (1,2) AND (lane_ids = 7 OR workflow_ids IN (3,4)) AND assignee = "alice"`.
+// Any board_ids/lane_ids/workflow_ids/card_ids comparisons in Query are
+// merged with the typed filters above and pushed down into the API request;
+// everything else is applied as an in-memory predicate over the fetched
+// cards.
+type GetCardsRequest struct {
+	BoardIDs    []int  `json:"board_ids,omitempty"`
+	LaneIDs     []int  `json:"lane_ids,omitempty"`
+	WorkflowIDs []int  `json:"workflow_ids,omitempty"`
+	CardIDs     []int  `json:"card_ids,omitempty"`
+	Query       string `json:"query,omitempty"`
+
+	// ModifiedSince, when set, restricts results to cards last modified at
+	// or after this time (rendered as modified_from_date on the API
+	// request). Subscribe uses this to resume polling from a persisted
+	// watermark instead of re-fetching every card on the board.
+	ModifiedSince *time.Time `json:"-"`
+}
+
+// GetCardsResponse is the raw BusinessMap API v2 response shape for
+// GET /api/v2/cards: a pagination block alongside the card list.
+type GetCardsResponse struct {
+	Data GetCardsData `json:"data"`
+}
+
+type GetCardsData struct {
+	Pagination Pagination    `json:"pagination"`
+	Data       []CardSummary `json:"data"`
+}
+
+type Pagination struct {
+	AllPages       int `json:"all_pages"`
+	CurrentPage    int `json:"current_page"`
+	ResultsPerPage int `json:"results_per_page"`
+}
+
+// CardSummary is the condensed card representation returned by the cards
+// list endpoint, as opposed to the full CardData returned for a single card.
+type CardSummary struct {
+	CardID      int    `json:"card_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	BoardID     int    `json:"board_id"`
+	LaneID      int    `json:"lane_id"`
+	WorkflowID  int    `json:"workflow_id"`
+
+	// LastModified is the raw last-modified timestamp the API reports for
+	// this card, kept as the unparsed string (rather than run through
+	// timeParser/TimestampPolicy like ReadCardResponse's fields) since
+	// Subscribe only needs it to advance a watermark and diff card-to-card,
+	// not to surface a typed time.Time to callers. Empty if the API didn't
+	// include one.
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// GetCardsWithRetryResponse wraps the cards list with retry metadata,
+// mirroring ReadCardWithRetryResponse.
+type GetCardsWithRetryResponse struct {
+	FilterUsed    string            `json:"filter_used"`
+	FilterValues  []int             `json:"filter_values"`
+	Attempts      map[string]int    `json:"attempts"`
+	WaitSeconds   float64           `json:"wait_seconds"`
+	RateLimitHits int               `json:"rate_limit_hits"`
+	Completed     map[string]bool   `json:"completed"`
+	PartialError  map[string]string `json:"partial_error,omitempty"`
+	// Reasons records, per sub-fetch, why its retry loop stopped. See
+	// ReadCardWithRetryResponse.Reasons.
+	Reasons map[string]string `json:"reasons"`
+	Cards   []CardSummary     `json:"cards"`
+	// RetryTrace records every attempt made fetching the cards page(s). See
+	// AttemptRecord.
+	RetryTrace []AttemptRecord `json:"retry_trace,omitempty"`
+}
+
+// CardStreamOptions configures GetCardsStream's pagination.
+type CardStreamOptions struct {
+	PageSize  int // cards requested per page (default: 100)
+	StartPage int // first page to fetch (default: 1)
+	MaxPages  int // stop after this many pages, 0 = no limit
+}
+
+// CardStreamProgress is reported to the emit callback after each page
+// GetCardsStream fetches, so a long-running caller (e.g. an MCP progress
+// notification) can show the retry loop grinding rather than going silent
+// until the whole result set is buffered.
+type CardStreamProgress struct {
+	Page        int   `json:"page"`
+	PerPage     int   `json:"per_page"`
+	Returned    int   `json:"returned"`
+	Cumulative  int   `json:"cumulative"`
+	Attempt     int   `json:"attempt"`
+	NextDelayMs int64 `json:"next_delay_ms"`
 }
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// WatermarkStore persists the last-seen timestamp per subscription key (a
+// board ID, or any caller-chosen scope) so a restarted Subscribe poller
+// doesn't replay history it already delivered.
+type WatermarkStore interface {
+	Get(ctx context.Context, key string) (time.Time, error)
+	Set(ctx context.Context, key string, t time.Time) error
+}
+
+// memoryWatermarkStore is the default WatermarkStore: it keeps watermarks in
+// memory only, so they don't survive a process restart.
+type memoryWatermarkStore struct {
+	mu    sync.Mutex
+	marks map[string]time.Time
+}
+
+// NewMemoryWatermarkStore returns a WatermarkStore backed by an in-process
+// map. Use FileWatermarkStore when watermarks need to survive a restart.
+func NewMemoryWatermarkStore() WatermarkStore {
+	return &memoryWatermarkStore{marks: make(map[string]time.Time)}
+}
+
+func (s *memoryWatermarkStore) Get(_ context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.marks[key], nil
+}
+
+func (s *memoryWatermarkStore) Set(_ context.Context, key string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marks[key] = t
+	return nil
+}
+
+// FileWatermarkStore persists watermarks as JSON in a single file, rewritten
+// in full on every Set. It's meant for a single-process subscriber; it does
+// not coordinate concurrent writers across processes.
+type FileWatermarkStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileWatermarkStore returns a WatermarkStore backed by the JSON file at
+// path, creating it on first Set if it doesn't exist.
+func NewFileWatermarkStore(path string) *FileWatermarkStore {
+	return &FileWatermarkStore{path: path}
+}
+
+func (s *FileWatermarkStore) Get(_ context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	marks, err := s.load()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return marks[key], nil
+}
+
+func (s *FileWatermarkStore) Set(_ context.Context, key string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	marks, err := s.load()
+	if err != nil {
+		return err
+	}
+	marks[key] = t
+
+	data, err := json.Marshal(marks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal watermarks: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write watermark file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileWatermarkStore) load() (map[string]time.Time, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return make(map[string]time.Time), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watermark file: %w", err)
+	}
+
+	marks := make(map[string]time.Time)
+	if len(data) == 0 {
+		return marks, nil
+	}
+	if err := json.Unmarshal(data, &marks); err != nil {
+		return nil, fmt.Errorf("failed to parse watermark file: %w", err)
+	}
+	return marks, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2018-present HiveMQ and the HiveMQ Community
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kanbanize
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// webhookPayload is the subset of BusinessMap's outbound webhook body this
+// handler understands: an event type plus the affected card.
+type webhookPayload struct {
+	Event string      `json:"event"`
+	Card  CardSummary `json:"card"`
+}
+
+var webhookEventKinds = map[string]EventKind{
+	"card_created":    EventCreated,
+	"card_updated":    EventUpdated,
+	"comment_added":   EventCommentAdded,
+	"subtask_changed": EventSubtaskChanged,
+}
+
+// WebhookHandler returns an http.Handler that decodes BusinessMap outbound
+// webhook deliveries and pushes the equivalent CardEvent onto sink, so
+// callers can consume the same CardEvent stream regardless of whether
+// changes arrive via Subscribe's polling loop or a push webhook.
+//
+// The handler responds 202 Accepted once the event is enqueued, 400 for a
+// malformed body or unrecognized event type, and 503 if sink is full.
+func WebhookHandler(sink chan<- CardEvent) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, "invalid webhook payload: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		kind, ok := webhookEventKinds[payload.Event]
+		if !ok {
+			http.Error(w, "unrecognized event type: "+payload.Event, http.StatusBadRequest)
+			return
+		}
+
+		card := payload.Card
+		event := CardEvent{Kind: kind, CardID: card.CardID, After: &card}
+
+		select {
+		case sink <- event:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.Error(w, "event sink is full", http.StatusServiceUnavailable)
+		}
+	})
+}
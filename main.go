@@ -18,7 +18,9 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	_ "embed"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -26,6 +28,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -35,6 +38,189 @@ import (
 	"github.com/hivemq/businessmap-mcp/internal/kanbanize"
 )
 
+// cancelRegistry tracks context.CancelFuncs for in-flight retry tool calls by
+// a caller-supplied cancel_token, so the companion cancel_retry tool can
+// interrupt a sleeping backoff loop and make it return early with whatever
+// partial data it has.
+type cancelRegistry struct {
+	mu     sync.Mutex
+	tokens map[string]context.CancelFunc
+}
+
+func newCancelRegistry() *cancelRegistry {
+	return &cancelRegistry{tokens: make(map[string]context.CancelFunc)}
+}
+
+// register associates token with cancel, overwriting any prior registration
+// under the same token. A blank token is a no-op, matching callers that
+// didn't opt into cancellation.
+func (r *cancelRegistry) register(token string, cancel context.CancelFunc) {
+	if token == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[token] = cancel
+}
+
+// unregister removes token once its retry call has returned, so cancel_retry
+// can no longer affect it.
+func (r *cancelRegistry) unregister(token string) {
+	if token == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, token)
+}
+
+// cancel invokes and removes the cancel func registered under token,
+// reporting whether one was found.
+func (r *cancelRegistry) cancel(token string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cancelFunc, ok := r.tokens[token]
+	if !ok {
+		return false
+	}
+	cancelFunc()
+	delete(r.tokens, token)
+	return true
+}
+
+// deriveCallContext applies a tool call's optional deadline_unix_ms and
+// cancel_token parameters on top of ctx. The returned cancel func must always
+// be deferred by the caller: it both releases the context.WithDeadline timer
+// and unregisters the cancel token, so a stale entry can't outlive the call.
+func deriveCallContext(ctx context.Context, request mcp.CallToolRequest, cancelTokens *cancelRegistry) (context.Context, context.CancelFunc) {
+	cancel := func() {}
+
+	if deadlineMs := mcp.ParseFloat64(request, "deadline_unix_ms", 0); deadlineMs > 0 {
+		var deadlineCancel context.CancelFunc
+		ctx, deadlineCancel = context.WithDeadline(ctx, time.UnixMilli(int64(deadlineMs)))
+		cancel = deadlineCancel
+	}
+
+	if token := mcp.ParseString(request, "cancel_token", ""); token != "" {
+		var tokenCancel context.CancelFunc
+		ctx, tokenCancel = context.WithCancel(ctx)
+		cancelTokens.register(token, tokenCancel)
+		prevCancel := cancel
+		cancel = func() {
+			cancelTokens.unregister(token)
+			tokenCancel()
+			prevCancel()
+		}
+	}
+
+	return ctx, cancel
+}
+
+// clientRegistry lazily builds and caches one kanbanize.Client per configured
+// profile (see config.Config.Profiles), so each workspace/tenant gets its own
+// circuit breaker, retry budget, and rate limiter instead of sharing one
+// client's state across all of them — a slow or over-quota tenant stays
+// contained to its own profile rather than starving the others.
+type clientRegistry struct {
+	cfg *config.Config
+
+	mu      sync.Mutex
+	clients map[string]*kanbanize.Client
+}
+
+func newClientRegistry(cfg *config.Config) *clientRegistry {
+	return &clientRegistry{cfg: cfg, clients: make(map[string]*kanbanize.Client)}
+}
+
+// get returns the kanbanize.Client for profile, building and caching it on
+// first use. An empty profile resolves to the registry's default via
+// config.Config.Profile, matching every tool's "profile" parameter.
+func (r *clientRegistry) get(profile string) (*kanbanize.Client, error) {
+	resolved := profile
+	if resolved == "" {
+		resolved = r.cfg.DefaultProfile
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if client, ok := r.clients[resolved]; ok {
+		return client, nil
+	}
+
+	pc, err := r.cfg.Profile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []kanbanize.Option
+	if pc.Timeout > 0 {
+		opts = append(opts, kanbanize.WithTimeout(pc.Timeout))
+	}
+	client := kanbanize.NewClient(pc.BaseURL, pc.APIKey, opts...)
+	if pc.RateLimitRPS > 0 {
+		burst := pc.RateLimitBurst
+		if burst <= 0 {
+			burst = 1
+		}
+		client.SetRateLimiter(kanbanize.NewTokenBucketRateLimiter(pc.RateLimitRPS, burst))
+	}
+
+	r.clients[resolved] = client
+	return client, nil
+}
+
+// cardStreamCursor captures enough of an in-progress get_cards_stream call to
+// resume it from the next unfetched page via get_cards_stream_next.
+type cardStreamCursor struct {
+	filter     kanbanize.GetCardsRequest
+	retryCfg   kanbanize.RetryConfig
+	pageSize   int
+	nextPage   int
+	cumulative int
+	returnMode string
+	profile    string
+}
+
+// cursorRegistry holds pending cardStreamCursors by a server-generated id.
+// Entries are single-use: take removes the entry it returns, matching
+// get_cards_stream_next's resume-then-discard semantics.
+type cursorRegistry struct {
+	mu      sync.Mutex
+	cursors map[string]*cardStreamCursor
+}
+
+func newCursorRegistry() *cursorRegistry {
+	return &cursorRegistry{cursors: make(map[string]*cardStreamCursor)}
+}
+
+func (r *cursorRegistry) store(c *cardStreamCursor) string {
+	id := generateCursorID()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cursors[id] = c
+	return id
+}
+
+func (r *cursorRegistry) take(id string) (*cardStreamCursor, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.cursors[id]
+	if ok {
+		delete(r.cursors, id)
+	}
+	return c, ok
+}
+
+// generateCursorID returns a random hex token identifying a cursorRegistry entry.
+func generateCursorID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("cursor-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
 //go:embed VERSION
 var versionFile string
 
@@ -52,6 +238,7 @@ func getVersion() string {
 
 func main() {
 	var showVersion = flag.Bool("version", false, "show version and exit")
+	var printSchema = flag.Bool("print-config-schema", false, "print an example BUSINESSMAP_CONFIG file and exit")
 	flag.Parse()
 
 	if *showVersion {
@@ -59,6 +246,13 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *printSchema {
+		if err := config.PrintSchema(os.Stdout); err != nil {
+			log.Fatalf("Failed to print config schema: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	_ = godotenv.Load()
 
 	cfg, err := config.Load()
@@ -66,7 +260,9 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	client := kanbanize.NewClient(cfg.KanbanizeBaseURL, cfg.KanbanizeAPIKey)
+	clients := newClientRegistry(cfg)
+	cancelTokens := newCancelRegistry()
+	streamCursors := newCursorRegistry()
 
 	mcpServer := server.NewMCPServer("kanbanize-mcp-server", getVersion())
 
@@ -76,6 +272,9 @@ func main() {
 			mcp.Required(),
 			mcp.Description("The ID of the Kanbanize card to read or full card URL"),
 		),
+		mcp.WithString("profile",
+			mcp.Description("Name of the configured Kanbanize profile/workspace to use (default: the server's default profile)"),
+		),
 	)
 
 	mcpServer.AddTool(readCardTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -84,6 +283,11 @@ func main() {
 			return mcp.NewToolResultError("card_id parameter is required"), nil
 		}
 
+		client, err := clients.get(mcp.ParseString(request, "profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError("Failed to resolve profile: "+err.Error()), nil
+		}
+
 		cardData, err := client.ReadCard(cardID)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to read card: "+err.Error()), nil
@@ -107,6 +311,9 @@ func main() {
 			mcp.Required(),
 			mcp.Description("The text of the comment to add"),
 		),
+		mcp.WithString("profile",
+			mcp.Description("Name of the configured Kanbanize profile/workspace to use (default: the server's default profile)"),
+		),
 	)
 
 	mcpServer.AddTool(addCommentTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -120,7 +327,12 @@ func main() {
 			return mcp.NewToolResultError("comment_text parameter is required"), nil
 		}
 
-		err := client.AddCardComment(cardID, commentText)
+		client, err := clients.get(mcp.ParseString(request, "profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError("Failed to resolve profile: "+err.Error()), nil
+		}
+
+		err = client.AddCardComment(cardID, commentText)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to add comment: "+err.Error()), nil
 		}
@@ -155,6 +367,15 @@ func main() {
 		mcp.WithBoolean("fail_on_partial",
 			mcp.Description("If true, abort when secondary endpoints fail (default: false)"),
 		),
+		mcp.WithNumber("deadline_unix_ms",
+			mcp.Description("Absolute Unix epoch milliseconds after which the call gives up, regardless of total_wait_cap_ms"),
+		),
+		mcp.WithString("cancel_token",
+			mcp.Description("Caller-supplied token; pass it to the cancel_retry tool to interrupt this call's backoff early"),
+		),
+		mcp.WithString("profile",
+			mcp.Description("Name of the configured Kanbanize profile/workspace to use (default: the server's default profile)"),
+		),
 	)
 
 	mcpServer.AddTool(readCardWithRetryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -163,6 +384,14 @@ func main() {
 			return mcp.NewToolResultError("card_id parameter is required"), nil
 		}
 
+		client, err := clients.get(mcp.ParseString(request, "profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError("Failed to resolve profile: "+err.Error()), nil
+		}
+
+		ctx, cancel := deriveCallContext(ctx, request, cancelTokens)
+		defer cancel()
+
 		// Build retry config with defaults
 		retryConfig := kanbanize.DefaultRetryConfig()
 
@@ -241,9 +470,26 @@ func main() {
 		mcp.WithBoolean("fail_on_partial",
 			mcp.Description("If true, abort when secondary endpoints fail (default: false)"),
 		),
+		mcp.WithNumber("deadline_unix_ms",
+			mcp.Description("Absolute Unix epoch milliseconds after which the call gives up, regardless of total_wait_cap_ms"),
+		),
+		mcp.WithString("cancel_token",
+			mcp.Description("Caller-supplied token; pass it to the cancel_retry tool to interrupt this call's backoff early"),
+		),
+		mcp.WithString("profile",
+			mcp.Description("Name of the configured Kanbanize profile/workspace to use (default: the server's default profile)"),
+		),
 	)
 
 	mcpServer.AddTool(getCardsWithRetryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := clients.get(mcp.ParseString(request, "profile", ""))
+		if err != nil {
+			return mcp.NewToolResultError("Failed to resolve profile: "+err.Error()), nil
+		}
+
+		ctx, cancel := deriveCallContext(ctx, request, cancelTokens)
+		defer cancel()
+
 		// Parse filter parameters
 		filter := kanbanize.GetCardsRequest{}
 
@@ -332,11 +578,259 @@ func main() {
 		return mcp.NewToolResultText(string(cardsJSON)), nil
 	})
 
+	getCardsStreamTool := mcp.NewTool("get_cards_stream",
+		mcp.WithDescription("Pages through cards matching filter criteria instead of buffering the whole result set like get_cards_with_retry, emitting a progress notification after each page. With return_mode=summary, returns only counts and a cursor_id that get_cards_stream_next can resume from."),
+		mcp.WithString("board_ids",
+			mcp.Description("Comma-separated board IDs to filter by (e.g., \"1,2,3\")"),
+		),
+		mcp.WithString("lane_ids",
+			mcp.Description("Comma-separated lane IDs to filter by (e.g., \"4,5,6\")"),
+		),
+		mcp.WithString("workflow_ids",
+			mcp.Description("Comma-separated workflow IDs to filter by (e.g., \"7,8,9\")"),
+		),
+		mcp.WithString("card_ids",
+			mcp.Description("Comma-separated card IDs to filter by (e.g., \"10,11,12\")"),
+		),
+		mcp.WithNumber("page_size",
+			mcp.Description("Cards requested per page (default: 100)"),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description("Stop after this many pages in this call (default: no limit)"),
+		),
+		mcp.WithString("return_mode",
+			mcp.Description("\"full\" returns the concatenated cards (default), \"summary\" returns only counts plus a cursor_id for get_cards_stream_next"),
+		),
+		mcp.WithNumber("max_attempts",
+			mcp.Description("Upper bound attempts per page (default: 10)"),
+		),
+		mcp.WithNumber("initial_delay_ms",
+			mcp.Description("Initial backoff in milliseconds (default: 5000)"),
+		),
+		mcp.WithNumber("max_delay_ms",
+			mcp.Description("Max single delay in milliseconds (default: 300000 = 5 min)"),
+		),
+		mcp.WithNumber("multiplier",
+			mcp.Description("Exponential growth factor (default: 2.0)"),
+		),
+		mcp.WithBoolean("respect_retry_after",
+			mcp.Description("Honor server Retry-After header if present (default: true)"),
+		),
+		mcp.WithNumber("total_wait_cap_ms",
+			mcp.Description("Global time cap in milliseconds, per page (default: 1200000 = 20 min)"),
+		),
+		mcp.WithString("profile",
+			mcp.Description("Name of the configured Kanbanize profile/workspace to use (default: the server's default profile)"),
+		),
+	)
+
+	mcpServer.AddTool(getCardsStreamTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		profile := mcp.ParseString(request, "profile", "")
+		client, err := clients.get(profile)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to resolve profile: "+err.Error()), nil
+		}
+
+		filter := kanbanize.GetCardsRequest{}
+
+		if boardIDsStr := mcp.ParseString(request, "board_ids", ""); boardIDsStr != "" {
+			ids, err := parseIntArray(boardIDsStr)
+			if err != nil {
+				return mcp.NewToolResultError("Invalid board_ids format: " + err.Error()), nil
+			}
+			filter.BoardIDs = ids
+		}
+		if laneIDsStr := mcp.ParseString(request, "lane_ids", ""); laneIDsStr != "" {
+			ids, err := parseIntArray(laneIDsStr)
+			if err != nil {
+				return mcp.NewToolResultError("Invalid lane_ids format: " + err.Error()), nil
+			}
+			filter.LaneIDs = ids
+		}
+		if workflowIDsStr := mcp.ParseString(request, "workflow_ids", ""); workflowIDsStr != "" {
+			ids, err := parseIntArray(workflowIDsStr)
+			if err != nil {
+				return mcp.NewToolResultError("Invalid workflow_ids format: " + err.Error()), nil
+			}
+			filter.WorkflowIDs = ids
+		}
+		if cardIDsStr := mcp.ParseString(request, "card_ids", ""); cardIDsStr != "" {
+			ids, err := parseIntArray(cardIDsStr)
+			if err != nil {
+				return mcp.NewToolResultError("Invalid card_ids format: " + err.Error()), nil
+			}
+			filter.CardIDs = ids
+		}
+		if len(filter.BoardIDs) == 0 && len(filter.LaneIDs) == 0 &&
+			len(filter.WorkflowIDs) == 0 && len(filter.CardIDs) == 0 {
+			return mcp.NewToolResultError("At least one filter parameter (board_ids, lane_ids, workflow_ids, or card_ids) must be provided"), nil
+		}
+
+		retryConfig := kanbanize.DefaultRetryConfig()
+		if maxAttempts := mcp.ParseFloat64(request, "max_attempts", 0); maxAttempts > 0 {
+			retryConfig.MaxAttempts = int(maxAttempts)
+		}
+		if initialDelayMs := mcp.ParseFloat64(request, "initial_delay_ms", 0); initialDelayMs > 0 {
+			retryConfig.InitialDelay = time.Duration(initialDelayMs) * time.Millisecond
+		}
+		if maxDelayMs := mcp.ParseFloat64(request, "max_delay_ms", 0); maxDelayMs > 0 {
+			retryConfig.MaxDelay = time.Duration(maxDelayMs) * time.Millisecond
+		}
+		if multiplier := mcp.ParseFloat64(request, "multiplier", 0); multiplier > 0 {
+			retryConfig.Multiplier = multiplier
+		}
+		if totalWaitCapMs := mcp.ParseFloat64(request, "total_wait_cap_ms", 0); totalWaitCapMs > 0 {
+			retryConfig.TotalWaitCap = time.Duration(totalWaitCapMs) * time.Millisecond
+		}
+		retryConfig.RespectRetryAfter = mcp.ParseBoolean(request, "respect_retry_after", true)
+
+		pageSize := int(mcp.ParseFloat64(request, "page_size", 100))
+		maxPages := int(mcp.ParseFloat64(request, "max_pages", 0))
+		returnMode := mcp.ParseString(request, "return_mode", "full")
+
+		opts := kanbanize.CardStreamOptions{PageSize: pageSize, StartPage: 1, MaxPages: maxPages}
+		cards, nextPage, err := client.GetCardsStream(ctx, filter, retryConfig, opts, streamProgressEmitter(ctx, mcpServer))
+		if err != nil {
+			return mcp.NewToolResultError("Failed to stream cards: "+err.Error()), nil
+		}
+
+		if returnMode == "summary" {
+			var cursorID string
+			if nextPage > 0 {
+				cursorID = streamCursors.store(&cardStreamCursor{
+					filter:     filter,
+					retryCfg:   retryConfig,
+					pageSize:   opts.PageSize,
+					nextPage:   nextPage,
+					cumulative: len(cards),
+					returnMode: returnMode,
+					profile:    profile,
+				})
+			}
+			summaryJSON, _ := json.Marshal(map[string]interface{}{
+				"returned":  len(cards),
+				"next_page": nextPage,
+				"cursor_id": cursorID,
+			})
+			return mcp.NewToolResultText(string(summaryJSON)), nil
+		}
+
+		cardsJSON, err := json.Marshal(cards)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to serialize cards data: "+err.Error()), nil
+		}
+		return mcp.NewToolResultText(string(cardsJSON)), nil
+	})
+
+	getCardsStreamNextTool := mcp.NewTool("get_cards_stream_next",
+		mcp.WithDescription("Resumes a get_cards_stream call started with return_mode=summary, continuing from the page after the one its cursor_id left off at."),
+		mcp.WithString("cursor_id",
+			mcp.Required(),
+			mcp.Description("The cursor_id returned by get_cards_stream or a prior get_cards_stream_next call"),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description("Stop after this many pages in this call (default: no limit)"),
+		),
+	)
+
+	mcpServer.AddTool(getCardsStreamNextTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		cursorID := mcp.ParseString(request, "cursor_id", "")
+		if cursorID == "" {
+			return mcp.NewToolResultError("cursor_id parameter is required"), nil
+		}
+		cursor, ok := streamCursors.take(cursorID)
+		if !ok {
+			return mcp.NewToolResultError("no pending stream found for cursor_id " + cursorID), nil
+		}
+
+		client, err := clients.get(cursor.profile)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to resolve profile: "+err.Error()), nil
+		}
+
+		maxPages := int(mcp.ParseFloat64(request, "max_pages", 0))
+		opts := kanbanize.CardStreamOptions{PageSize: cursor.pageSize, StartPage: cursor.nextPage, MaxPages: maxPages}
+		cards, nextPage, err := client.GetCardsStream(ctx, cursor.filter, cursor.retryCfg, opts, streamProgressEmitter(ctx, mcpServer))
+		if err != nil {
+			return mcp.NewToolResultError("Failed to resume card stream: "+err.Error()), nil
+		}
+
+		cumulative := cursor.cumulative + len(cards)
+
+		if cursor.returnMode == "summary" {
+			var newCursorID string
+			if nextPage > 0 {
+				newCursorID = streamCursors.store(&cardStreamCursor{
+					filter:     cursor.filter,
+					retryCfg:   cursor.retryCfg,
+					pageSize:   cursor.pageSize,
+					nextPage:   nextPage,
+					cumulative: cumulative,
+					returnMode: cursor.returnMode,
+					profile:    cursor.profile,
+				})
+			}
+			summaryJSON, _ := json.Marshal(map[string]interface{}{
+				"returned":   len(cards),
+				"cumulative": cumulative,
+				"next_page":  nextPage,
+				"cursor_id":  newCursorID,
+			})
+			return mcp.NewToolResultText(string(summaryJSON)), nil
+		}
+
+		cardsJSON, err := json.Marshal(cards)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to serialize cards data: "+err.Error()), nil
+		}
+		return mcp.NewToolResultText(string(cardsJSON)), nil
+	})
+
+	cancelRetryTool := mcp.NewTool("cancel_retry",
+		mcp.WithDescription("Interrupts an in-flight read_card_with_retry or get_cards_with_retry call that was started with a cancel_token, making it return early with whatever partial data it has."),
+		mcp.WithString("cancel_token",
+			mcp.Required(),
+			mcp.Description("The cancel_token passed to the retry tool call to interrupt"),
+		),
+	)
+
+	mcpServer.AddTool(cancelRetryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		token := mcp.ParseString(request, "cancel_token", "")
+		if token == "" {
+			return mcp.NewToolResultError("cancel_token parameter is required"), nil
+		}
+
+		if !cancelTokens.cancel(token) {
+			return mcp.NewToolResultError("no in-flight call found for cancel_token " + token), nil
+		}
+
+		return mcp.NewToolResultText("canceled"), nil
+	})
+
 	if err := server.ServeStdio(mcpServer); err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }
 
+// streamProgressEmitter adapts a CardStreamProgress callback into an MCP
+// progress notification on srv, shared by get_cards_stream and
+// get_cards_stream_next so both tools report pages the same way.
+func streamProgressEmitter(ctx context.Context, srv *server.MCPServer) func(kanbanize.CardStreamProgress) {
+	return func(p kanbanize.CardStreamProgress) {
+		params := map[string]interface{}{
+			"page":          p.Page,
+			"per_page":      p.PerPage,
+			"returned":      p.Returned,
+			"cumulative":    p.Cumulative,
+			"attempt":       p.Attempt,
+			"next_delay_ms": p.NextDelayMs,
+		}
+		if err := srv.SendNotificationToClient(ctx, "notifications/cards_stream/progress", params); err != nil {
+			log.Printf("[STREAM] failed to send progress notification: %v", err)
+		}
+	}
+}
+
 // parseIntArray parses a comma-separated string of integers into a slice
 func parseIntArray(s string) ([]int, error) {
 	if s == "" {